@@ -1,16 +1,21 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type KeyViperConfig interface {
 	GetBool(key string) bool
 	GetInt(key string) int
+	GetFloat64(key string) float64
 	GetString(key string) string
 	GetStringSlice(key string) []string
 	GetUInt64(key string) uint64
 	GetStringMap(key string) map[string]interface{}
+	GetStringMapString(key string) map[string]string
+	GetDuration(key string) time.Duration
 	InitConfig()
 }
 
@@ -35,6 +40,10 @@ func (vr *viperConfig) GetInt(key string) int {
 	return viper.GetInt(key)
 }
 
+func (vr *viperConfig) GetFloat64(key string) float64 {
+	return viper.GetFloat64(key)
+}
+
 func (vr *viperConfig) GetString(key string) string {
 	return viper.GetString(key)
 }
@@ -51,6 +60,14 @@ func (vr *viperConfig) GetStringMap(key string) map[string]interface{} {
 	return viper.GetStringMap(key)
 }
 
+func (vr *viperConfig) GetStringMapString(key string) map[string]string {
+	return viper.GetStringMapString(key)
+}
+
+func (vr *viperConfig) GetDuration(key string) time.Duration {
+	return viper.GetDuration(key)
+}
+
 func ConfigViper() KeyViperConfig {
 	vr := &viperConfig{}
 	vr.InitConfig()
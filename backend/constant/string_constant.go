@@ -1,14 +1,132 @@
 package constant
 
 const (
-	ErrorDatabase     = "error_database"
-	ErrorInvalidInput = "error_invalid_input"
+	ErrorDatabase             = "error_database"
+	ErrorInvalidInput         = "error_invalid_input"
+	ErrorInvalidISBN          = "error_invalid_isbn"
+	ErrorImportFile           = "error_import_file"
+	ErrorUnsupportedPatch     = "error_unsupported_patch"
+	ErrorPreconditionFailed   = "error_precondition_failed"
+	ErrorPreconditionRequired = "error_precondition_required"
+	ErrorUnknownAuthor        = "error_unknown_author"
+	ErrorUnknownGenre         = "error_unknown_genre"
+	ErrorGenreCycle           = "error_genre_cycle"
+	ErrorUnknownWork          = "error_unknown_work"
+	ErrorUnknownSeries        = "error_unknown_series"
+	ErrorCoverTooLarge        = "error_cover_too_large"
+	ErrorUnsupportedCover     = "error_unsupported_cover"
+	ErrorInvalidCover         = "error_invalid_cover"
+	ErrorCoverSize            = "error_cover_size"
 
 	SuccessAddUrl = "success_add_url"
 
-	SuccessAddBook    = "success_add_book"
-	SuccessGetBook    = "success_get_book"
-	SuccessUpdateBook = "success_update_book"
-	SuccessDeleteBook = "success_delete_book"
-	NotfoundBook      = "notfound_book"
+	SuccessAddBook     = "success_add_book"
+	SuccessGetBook     = "success_get_book"
+	SuccessUpdateBook  = "success_update_book"
+	SuccessDeleteBook  = "success_delete_book"
+	SuccessImportBook  = "success_import_book"
+	SuccessRestoreBook = "success_restore_book"
+	NotfoundBook       = "notfound_book"
+	NotfoundTrashBook  = "notfound_trash_book"
+	ConflictISBN       = "conflict_isbn"
+	ConflictBookOnLoan = "conflict_book_on_loan"
+
+	SuccessUploadCover = "success_upload_cover"
+	NotfoundCover      = "notfound_cover"
+
+	SuccessGetBookHistory = "success_get_book_history"
+	SuccessRevertBook     = "success_revert_book"
+	NotfoundRevision      = "notfound_revision"
+
+	SuccessAddCopy      = "success_add_copy"
+	SuccessGetCopy      = "success_get_copy"
+	SuccessUpdateCopy   = "success_update_copy"
+	SuccessDeleteCopy   = "success_delete_copy"
+	NotfoundCopy        = "notfound_copy"
+	ConflictBarcode     = "conflict_barcode"
+	ConflictCopyOnLoan  = "conflict_copy_on_loan"
+	ConflictCopyChanged = "conflict_copy_changed"
+
+	SuccessCheckoutLoan      = "success_checkout_loan"
+	SuccessReturnLoan        = "success_return_loan"
+	SuccessRenewLoan         = "success_renew_loan"
+	SuccessGetLoan           = "success_get_loan"
+	NotfoundLoan             = "notfound_loan"
+	ForbiddenLoan            = "forbidden_loan"
+	ConflictCopyNotAvailable = "conflict_copy_not_available"
+	ConflictCopyStatus       = "conflict_copy_status"
+	ConflictLoanLimit        = "conflict_loan_limit"
+	ConflictLoanNotActive    = "conflict_loan_not_active"
+	ConflictRenewalLimit     = "conflict_renewal_limit"
+	ConflictRenewalHolds     = "conflict_renewal_holds"
+
+	SuccessPlaceHold          = "success_place_hold"
+	SuccessGetHold            = "success_get_hold"
+	SuccessCancelHold         = "success_cancel_hold"
+	NotfoundHold              = "notfound_hold"
+	ForbiddenHold             = "forbidden_hold"
+	ConflictHold              = "conflict_hold"
+	ConflictHoldCopyAvailable = "conflict_hold_copy_available"
+	ConflictHoldNotActive     = "conflict_hold_not_active"
+
+	SuccessGetFine    = "success_get_fine"
+	SuccessWaiveFine  = "success_waive_fine"
+	SuccessAdjustFine = "success_adjust_fine"
+	ConflictFineLimit = "conflict_fine_limit"
+	ConflictFineWaive = "conflict_fine_waive"
+
+	SuccessAddAuthor       = "success_add_author"
+	SuccessGetAuthor       = "success_get_author"
+	SuccessUpdateAuthor    = "success_update_author"
+	SuccessDeleteAuthor    = "success_delete_author"
+	NotfoundAuthor         = "notfound_author"
+	ConflictAuthorHasBooks = "conflict_author_has_books"
+
+	SuccessAddGenre    = "success_add_genre"
+	SuccessGetGenre    = "success_get_genre"
+	SuccessUpdateGenre = "success_update_genre"
+	SuccessDeleteGenre = "success_delete_genre"
+	NotfoundGenre      = "notfound_genre"
+	ConflictGenre      = "conflict_genre"
+	ConflictGenreInUse = "conflict_genre_in_use"
+
+	SuccessAddWork          = "success_add_work"
+	SuccessGetWork          = "success_get_work"
+	SuccessUpdateWork       = "success_update_work"
+	SuccessDeleteWork       = "success_delete_work"
+	NotfoundWork            = "notfound_work"
+	ConflictWorkHasEditions = "conflict_work_has_editions"
+
+	SuccessAddSeries       = "success_add_series"
+	SuccessGetSeries       = "success_get_series"
+	SuccessUpdateSeries    = "success_update_series"
+	SuccessDeleteSeries    = "success_delete_series"
+	NotfoundSeries         = "notfound_series"
+	ConflictSeriesHasWorks = "conflict_series_has_works"
+
+	SuccessAddShelf        = "success_add_shelf"
+	SuccessGetShelf        = "success_get_shelf"
+	SuccessUpdateShelf     = "success_update_shelf"
+	SuccessDeleteShelf     = "success_delete_shelf"
+	NotfoundShelf          = "notfound_shelf"
+	ForbiddenShelf         = "forbidden_shelf"
+	ConflictShelf          = "conflict_shelf"
+	ConflictDefaultShelf   = "conflict_default_shelf"
+	SuccessAddShelfBook    = "success_add_shelf_book"
+	SuccessGetShelfBook    = "success_get_shelf_book"
+	SuccessUpdateShelfBook = "success_update_shelf_book"
+	SuccessRemoveShelfBook = "success_remove_shelf_book"
+	NotfoundShelfBook      = "notfound_shelf_book"
+	ConflictShelfBook      = "conflict_shelf_book"
+
+	SuccessAddReview    = "success_add_review"
+	SuccessGetReview    = "success_get_review"
+	SuccessUpdateReview = "success_update_review"
+	SuccessDeleteReview = "success_delete_review"
+	NotfoundReview      = "notfound_review"
+	ForbiddenReview     = "forbidden_review"
+	ConflictReview      = "conflict_review"
+
+	SuccessGetSimilarBook    = "success_get_similar_book"
+	SuccessGetRecommendation = "success_get_recommendation"
 )
@@ -0,0 +1,328 @@
+package authors
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuthorsController struct {
+	Validate   *validator.Validate
+	Repository repository.AuthorRepository
+	Books      repository.BookRepository
+}
+
+// GetAuthorsHandler godoc
+// @Summary Get all authors
+// @Description Get a page of authors ordered by name, q matches the name and its variants ignoring case, accents and punctuation
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param q query string false "Name or variant to look for"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Authors per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.Authors,meta=helpers.Pagination} "Authors retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors [get]
+func (h *AuthorsController) GetAuthorsHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	authors, err := h.Repository.List(ctx.Request.Context(), strings.TrimSpace(ctx.Query("q")), page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetAuthor, authors.Authors, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: authors.Total,
+	})
+}
+
+// AddAuthorHandler godoc
+// @Summary Add an author
+// @Description Add an author with the other spellings of their name found on books, a biography, birth and death years and authority identifiers. Needs the admin role.
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param author body entity.Author true "Author data"
+// @Success 201 {object} helpers.Response{data=entity.Authors} "Author added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors [post]
+func (h *AuthorsController) AddAuthorHandler(ctx *gin.Context) {
+	author, ok := h.bindAuthor(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := h.Repository.Create(ctx.Request.Context(), author)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	created, err := h.Repository.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddAuthor, created)
+}
+
+// GetAuthorHandler godoc
+// @Summary Get an author
+// @Description Get a single author by ID
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param id path string true "Author ID"
+// @Success 200 {object} helpers.Response{data=entity.Authors} "Author retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Author not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors/{id} [get]
+func (h *AuthorsController) GetAuthorHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	author, err := h.Repository.Get(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrAuthorNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundAuthor)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetAuthor, author)
+}
+
+// UpdateAuthorHandler godoc
+// @Summary Update an author
+// @Description Replace the name, variants, biography, years and identifiers of an author. Needs the admin role.
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Author ID"
+// @Param author body entity.Author true "Author data"
+// @Success 200 {object} helpers.Response{data=entity.Authors} "Author updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Author not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors/{id} [put]
+func (h *AuthorsController) UpdateAuthorHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	author, ok := h.bindAuthor(ctx)
+	if !ok {
+		return
+	}
+
+	err = h.Repository.Update(ctx.Request.Context(), id, author)
+	if err != nil {
+		if err == repository.ErrAuthorNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundAuthor)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	updated, err := h.Repository.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateAuthor, updated)
+}
+
+// DeleteAuthorHandler godoc
+// @Summary Delete an author
+// @Description Delete an author, authors still referenced by a book or a book in the trash cannot be deleted. Needs the admin role.
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Author ID"
+// @Success 200 {object} helpers.Response "Author deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Author not found"
+// @Failure 409 {object} helpers.Response "Books reference the author"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors/{id} [delete]
+func (h *AuthorsController) DeleteAuthorHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Repository.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrAuthorNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundAuthor)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	referenced, err := h.hasBooks(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if referenced {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictAuthorHasBooks)
+		return
+	}
+
+	err = h.Repository.Delete(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrAuthorNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundAuthor)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteAuthor, nil)
+}
+
+// GetAuthorBooksHandler godoc
+// @Summary Get the books of an author
+// @Description Get a page of the books linked to an author, ordered by title
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param id path string true "Author ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Books per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.Books,meta=helpers.Pagination} "Books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Author not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /authors/{id}/books [get]
+func (h *AuthorsController) GetAuthorBooksHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Repository.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrAuthorNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundAuthor)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	books, err := h.Books.List(ctx.Request.Context(), repository.ListOptions{
+		Filter: repository.BookFilter{AuthorID: id},
+		Sort:   []repository.SortField{{Field: "title"}},
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBook, books.Books, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: books.Total,
+	})
+}
+
+// bindAuthor reads and validates the author of the request body, the error response is already written when ok is false
+func (h *AuthorsController) bindAuthor(ctx *gin.Context) (author entity.Author, ok bool) {
+	if err := ctx.ShouldBindJSON(&author); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return author, false
+	}
+
+	author.Name = strings.TrimSpace(author.Name)
+	author.Variants = cleanVariants(author.Name, author.Variants)
+
+	// Validate input
+	if err := h.Validate.Struct(author); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return author, false
+	}
+	if author.BirthYear != nil && author.DeathYear != nil && *author.DeathYear < *author.BirthYear {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return author, false
+	}
+	return author, true
+}
+
+// cleanVariants trims the variants and drops the empty ones and those repeating the name or another variant
+func cleanVariants(name string, variants []string) []string {
+	cleaned := []string{}
+	seen := map[string]bool{name: true}
+	for _, variant := range variants {
+		variant = strings.TrimSpace(variant)
+		if variant != "" && !seen[variant] {
+			seen[variant] = true
+			cleaned = append(cleaned, variant)
+		}
+	}
+	return cleaned
+}
+
+// hasBooks reports whether a book, deleted or not, references the author
+func (h *AuthorsController) hasBooks(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	for _, trashed := range []bool{false, true} {
+		books, err := h.Books.List(ctx, repository.ListOptions{
+			Filter: repository.BookFilter{AuthorID: id, Trashed: trashed},
+			Page:   1,
+			Limit:  1,
+		})
+		if err != nil {
+			return false, err
+		}
+		if books.Total > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
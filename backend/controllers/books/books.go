@@ -1,27 +1,58 @@
 package books
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"library-books/constant"
 	"library-books/database/mongodb"
 	"library-books/entity"
 	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
 	"library-books/services"
+	"library-books/storage"
+	"library-books/utils"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/text/language"
 )
 
 var Validate *validator.Validate
 
+// errUnknownAuthor is returned by linkAuthors when a book references an author that does not exist
+var errUnknownAuthor = errors.New("unknown author id")
+
+// errUnknownGenre is returned by checkGenre when the genre of a book is not in the taxonomy
+var errUnknownGenre = errors.New("unknown genre")
+
 type BooksController struct {
-	Validate *validator.Validate
+	Validate   *validator.Validate
+	Repository repository.BookRepository
+	Revisions  repository.RevisionRepository
+	// Transactor saves every book change together with its revision
+	Transactor repository.Transactor
+	Copies     repository.CopyRepository
+	Holds      repository.HoldRepository
+	Authors    repository.AuthorRepository
+	Genres     repository.GenreRepository
+	Works      repository.WorkRepository
+	Series     repository.SeriesRepository
+	Reviews    repository.ReviewRepository
+	Covers     storage.BlobStore
+	// CoverCache keeps the resized covers, the ones of a book are cleared when its cover is replaced
+	CoverCache storage.BlobStore
+	// CoverPolicy limits the uploaded covers
+	CoverPolicy CoverPolicy
+	// PickupWindow is how long a copy made available stays set aside for the next hold
+	PickupWindow time.Duration
 }
 
 // AddUrlHandler godoc
@@ -58,7 +89,7 @@ func (h *BooksController) AddUrlHandler(ctx *gin.Context) {
 		URL:       req.URL,
 		Operation: req.Operation,
 		Response:  entity.URLResponse{ProcessedURL: processed},
-		CreatedAt: time.Now().String(),
+		CreatedAt: time.Now().UTC(),
 	}
 	_, err = mongodb.Database.Collection("urls").InsertOne(context.Background(), URLs)
 	if err != nil {
@@ -75,9 +106,12 @@ func (h *BooksController) AddUrlHandler(ctx *gin.Context) {
 // @Tags Books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param book body entity.Book true "Book data"
 // @Success 201 {object} helpers.Response "Book added successfully"
 // @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 409 {object} helpers.Response "A book with this ISBN already exists"
 // @Failure 500 {object} helpers.Response "Database error"
 // @Router /books [post]
 func (h *BooksController) AddBookHandler(ctx *gin.Context) {
@@ -93,10 +127,40 @@ func (h *BooksController) AddBookHandler(ctx *gin.Context) {
 		return
 	}
 
-	// Insert courier data into database
-	book.CreatedAt = time.Now().String()
-	_, err := mongodb.Database.Collection("books").InsertOne(context.Background(), book)
+	if err := h.resolveReferences(ctx.Request.Context(), &book); err != nil {
+		if err == errUnknownGenre {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+			return
+		}
+		if err == errUnknownAuthor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownAuthor)
+			return
+		}
+		if err == errUnknownWork {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// Insert book data into database
+	normalizeBookISBN(&book)
+	book.CreatedAt = time.Now().UTC()
+	err := h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		id, err := h.Repository.Create(txCtx, book)
+		if err != nil {
+			return err
+		}
+		createdBook := book.Books(id)
+		createdBook.Version = 1
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionCreate, entity.Book{}, createdBook)
+	})
 	if err != nil {
+		if err == repository.ErrDuplicateISBN {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictISBN)
+			return
+		}
 		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
@@ -106,42 +170,141 @@ func (h *BooksController) AddBookHandler(ctx *gin.Context) {
 
 // GetAllBookHandler godoc
 // @Summary Get all books
-// @Description Get all books from the library
+// @Description Get a page of books from the library, with optional filtering and sorting
 // @Tags Books
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Param after query string false "Cursor: return the books after this book ID, page is ignored"
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending (e.g. year,-title)"
+// @Param collapseEditions query bool false "List one book per work with its editionCount, cannot be combined with after"
+// @Param author query string false "Filter by author (partial, case insensitive)"
+// @Param authorId query string false "Filter by linked author ID"
+// @Param workId query string false "Filter by work ID, the editions of a work"
+// @Param genre query string false "Filter by genre slug, books of its subgenres included"
+// @Param isbn query string false "Filter by ISBN"
+// @Param yearFrom query int false "Filter books published in or after this year"
+// @Param yearTo query int false "Filter books published in or before this year"
+// @Param createdAfter query string false "Filter books created after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param updatedBefore query string false "Filter books last updated before this time (RFC 3339 or YYYY-MM-DD)"
 // @Success 200 {object} helpers.Response "Books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
 // @Failure 500 {object} helpers.Response "Database error"
 // @Router /books [get]
 func (h *BooksController) GetAllBookHandler(ctx *gin.Context) {
-	// Fetch books data from database
-	var formdataBooks []entity.Books
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	if err := h.expandGenres(ctx.Request.Context(), &opts.Filter); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
 
-	cursor, err := mongodb.Database.Collection("books").Find(context.Background(), bson.M{})
+	// Fetch books data from database
+	page, err := h.Repository.List(ctx.Request.Context(), opts)
 	if err != nil {
+		if err == repository.ErrInvalidCursor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	books := make([]*entity.Books, len(page.Books))
+	for i := range page.Books {
+		books[i] = &page.Books[i]
+	}
+	if err := h.setAvailability(ctx.Request.Context(), books); err != nil {
 		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
 
-	defer cursor.Close(context.Background())
+	pagination := helpers.Pagination{
+		Limit:      opts.Limit,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	if opts.After == nil {
+		pagination.Page = opts.Page
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBook, page.Books, pagination)
+}
 
-	// Iterate over the cursor and decode each item into a new item variable
-	for cursor.Next(context.Background()) {
-		var itemBook entity.Books
-		if err := cursor.Decode(&itemBook); err != nil {
-			continue
+// SearchBookHandler godoc
+// @Summary Search books
+// @Description Full-text search over title, author and description ordered by relevance, with highlighted fragments. Fragments are HTML: the book text is escaped and the matched words are wrapped in em tags. Words are stemmed in English or Indonesian, chosen by the lang query or the Accept-Language header.
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Param q query string true "Search terms"
+// @Param lang query string false "Search language (en or id)"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.BookSearchResult} "Books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/search [get]
+func (h *BooksController) SearchBookHandler(ctx *gin.Context) {
+	text := strings.TrimSpace(ctx.Query("q"))
+	if text == "" {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	query := repository.SearchQuery{
+		Text:     text,
+		Language: searchLanguage(ctx),
+		Page:     page,
+		Limit:    limit,
+	}
+	result, err := h.Repository.Search(ctx.Request.Context(), query)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// highlight the words sharing a stem with the query
+	stems := services.QueryStems(query.Text, query.Language)
+	books := make([]entity.BookSearchResult, 0, len(result.Results))
+	for _, item := range result.Results {
+		highlights := map[string][]string{}
+		for field, text := range map[string]string{
+			"title":       item.Book.Title,
+			"author":      item.Book.Author,
+			"description": item.Book.Description,
+		} {
+			if fragments := services.Highlight(text, stems, query.Language); len(fragments) > 0 {
+				highlights[field] = fragments
+			}
 		}
-		formdataBooks = append(formdataBooks, itemBook)
+		books = append(books, entity.BookSearchResult{Books: item.Book, Score: item.Score, Highlights: highlights})
 	}
 
-	// Check if there are any items
-	lengthData := len(formdataBooks)
-	if lengthData == 0 {
-		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+	found := make([]*entity.Books, len(books))
+	for i := range books {
+		found[i] = &books[i].Books
+	}
+	if err := h.setAvailability(ctx.Request.Context(), found); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
 
-	helpers.Success(ctx, http.StatusOK, constant.SuccessGetBook, formdataBooks)
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBook, books, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: result.Total,
+	})
 }
 
 // GetBookHandler godoc
@@ -151,7 +314,10 @@ func (h *BooksController) GetAllBookHandler(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Book ID"
+// @Param If-None-Match header string false "Answer 304 when the book ETag matches"
+// @Param If-Modified-Since header string false "Answer 304 when the book was not modified since this date"
 // @Success 200 {object} helpers.Response "Book retrieved successfully"
+// @Success 304 "Book not modified"
 // @Failure 400 {object} helpers.Response "Invalid input"
 // @Failure 404 {object} helpers.Response "Book not found"
 // @Failure 500 {object} helpers.Response "Database error"
@@ -170,11 +336,52 @@ func (h *BooksController) GetBookHandler(ctx *gin.Context) {
 		return
 	}
 
-	var book entity.Book
-	filter := bson.M{"_id": objectId}
-	err = mongodb.Database.Collection("books").FindOne(context.Background(), filter).Decode(&book)
+	book, err := h.Repository.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+		} else {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		}
+		return
+	}
+
+	if err := h.setAvailability(ctx.Request.Context(), []*entity.Books{&book}); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if err := h.setEditions(ctx.Request.Context(), &book); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	ctx.Header("ETag", bookReadETag(book))
+	setLastModified(ctx, book)
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetBook, book)
+}
+
+// GetBookByISBNHandler godoc
+// @Summary Get a book by ISBN
+// @Description Get a book by its ISBN-10 or ISBN-13, with or without hyphens
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Success 200 {object} helpers.Response "Book retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid ISBN"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/isbn/{isbn} [get]
+func (h *BooksController) GetBookByISBNHandler(ctx *gin.Context) {
+	isbn, ok := utils.NormalizeISBN(ctx.Param("isbn"))
+	if !ok {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidISBN)
+		return
+	}
+
+	book, err := h.Repository.GetByISBN(ctx.Request.Context(), isbn)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		if err == repository.ErrBookNotFound {
 			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
 		} else {
 			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
@@ -182,6 +389,17 @@ func (h *BooksController) GetBookHandler(ctx *gin.Context) {
 		return
 	}
 
+	if err := h.setAvailability(ctx.Request.Context(), []*entity.Books{&book}); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if err := h.setEditions(ctx.Request.Context(), &book); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	ctx.Header("ETag", bookReadETag(book))
+	setLastModified(ctx, book)
 	helpers.Success(ctx, http.StatusOK, constant.SuccessGetBook, book)
 }
 
@@ -191,12 +409,18 @@ func (h *BooksController) GetBookHandler(ctx *gin.Context) {
 // @Tags Books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Book ID"
+// @Param If-Match header string true "ETag of the book version being changed"
 // @Param book body entity.Book true "Book data"
 // @Success 200 {object} helpers.Response "Book updated successfully"
 // @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
 // @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "A book with this ISBN already exists"
 // @Failure 500 {object} helpers.Response "Database error"
+// @Failure 412 {object} helpers.Response "Book changed since the given version"
+// @Failure 428 {object} helpers.Response "If-Match header missing"
 // @Router /books/{id} [put]
 func (h *BooksController) UpdateBookHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -224,10 +448,9 @@ func (h *BooksController) UpdateBookHandler(ctx *gin.Context) {
 	}
 
 	// Fetch existing book
-	var existingBook entity.Book
-	err = mongodb.Database.Collection("books").FindOne(context.Background(), bson.M{"_id": objectId}).Decode(&existingBook)
+	existingBook, err := h.Repository.Get(ctx.Request.Context(), objectId)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		if err == repository.ErrBookNotFound {
 			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
 			return
 		}
@@ -235,34 +458,213 @@ func (h *BooksController) UpdateBookHandler(ctx *gin.Context) {
 		return
 	}
 
+	// Require the version the client last read
+	if present, matches := ifMatch(ctx, bookETag(existingBook.Version)); !present {
+		helpers.PreconditionRequired(ctx, http.StatusPreconditionRequired, constant.ErrorPreconditionRequired)
+		return
+	} else if !matches {
+		helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+		return
+	}
+
+	if err := h.resolveReferences(ctx.Request.Context(), &book); err != nil {
+		if err == errUnknownGenre {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+			return
+		}
+		if err == errUnknownAuthor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownAuthor)
+			return
+		}
+		if err == errUnknownWork {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
 	// Update book
-	book.UpdatedAt = time.Now().String()
-	filter := bson.M{"_id": objectId}
-	update := bson.M{"$set": book}
-	result, err := mongodb.Database.Collection("books").UpdateOne(context.Background(), filter, update)
+	keepServerFields(&book, existingBook)
+	updatedBook := book.Books(objectId)
+	updatedBook.Version = existingBook.Version + 1
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Update(txCtx, objectId, book, existingBook.Version); err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionUpdate, existingBook.Book(), updatedBook)
+	})
 	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		if err == repository.ErrDuplicateISBN {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictISBN)
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+			return
+		}
 		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
-	if result.MatchedCount == 0 {
-		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+
+	ctx.Header("ETag", bookETag(updatedBook.Version))
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateBook, nil)
+}
+
+// PatchBookHandler godoc
+// @Summary Partially update a book by ID
+// @Description Apply a JSON Merge Patch (RFC 7396, Content-Type application/merge-patch+json) or a JSON Patch (RFC 6902, Content-Type application/json-patch+json) to a book. The patched book is validated before saving, server managed fields (id, createdAt, updatedAt, originalIsbn) cannot be changed.
+// @Tags Books
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param If-Match header string true "ETag of the book version being changed"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} helpers.Response{data=entity.Books} "Book updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "A book with this ISBN already exists"
+// @Failure 415 {object} helpers.Response "Unsupported patch format"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Failure 412 {object} helpers.Response "Book changed since the given version"
+// @Failure 428 {object} helpers.Response "If-Match header missing"
+// @Router /books/{id} [patch]
+func (h *BooksController) PatchBookHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
 		return
 	}
 
-	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateBook, nil)
+	var applyPatch func(document []byte, patch []byte) ([]byte, error)
+	switch ctx.ContentType() {
+	case "application/merge-patch+json":
+		applyPatch = utils.MergePatch
+	case "application/json-patch+json":
+		applyPatch = utils.ApplyJSONPatch
+	default:
+		helpers.UnsupportedMediaType(ctx, http.StatusUnsupportedMediaType, constant.ErrorUnsupportedPatch)
+		return
+	}
+
+	patch, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	// Fetch existing book
+	existingBook, err := h.Repository.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// Require the version the client last read
+	if present, matches := ifMatch(ctx, bookETag(existingBook.Version)); !present {
+		helpers.PreconditionRequired(ctx, http.StatusPreconditionRequired, constant.ErrorPreconditionRequired)
+		return
+	} else if !matches {
+		helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+		return
+	}
+
+	book, err := patchBook(existingBook, patch, applyPatch)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	// Validate the patched book
+	if err := h.Validate.Struct(book); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if err := h.resolveReferences(ctx.Request.Context(), &book); err != nil {
+		if err == errUnknownGenre {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+			return
+		}
+		if err == errUnknownAuthor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownAuthor)
+			return
+		}
+		if err == errUnknownWork {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// Update book
+	keepServerFields(&book, existingBook)
+	var updatedBook entity.Books
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Update(txCtx, objectId, book, existingBook.Version); err != nil {
+			return err
+		}
+		var err error
+		if updatedBook, err = h.Repository.Get(txCtx, objectId); err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionUpdate, existingBook.Book(), updatedBook)
+	})
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		if err == repository.ErrDuplicateISBN {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictISBN)
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	if err := h.setAvailability(ctx.Request.Context(), []*entity.Books{&updatedBook}); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	ctx.Header("ETag", bookReadETag(updatedBook))
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateBook, updatedBook)
 }
 
 // DeleteBookHandler godoc
 // @Summary Delete a book by ID
-// @Description Delete a book by its ID from the library
+// @Description Move a book to the trash, it is hidden from the library until restored and permanently removed with its copies after the retention window. A book with a copy on loan or on hold cannot be deleted.
 // @Tags Books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Book ID"
+// @Param If-Match header string true "ETag of the book version being changed"
 // @Success 200 {object} helpers.Response "Book deleted successfully"
 // @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
 // @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "A copy of the book is on loan or on hold"
 // @Failure 500 {object} helpers.Response "Database error"
+// @Failure 412 {object} helpers.Response "Book changed since the given version"
+// @Failure 428 {object} helpers.Response "If-Match header missing"
 // @Router /books/{id} [delete]
 func (h *BooksController) DeleteBookHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -277,16 +679,292 @@ func (h *BooksController) DeleteBookHandler(ctx *gin.Context) {
 		return
 	}
 
-	filter := bson.M{"_id": objectId}
-	result, err := mongodb.Database.Collection("books").DeleteOne(context.Background(), filter)
+	// Fetch existing book
+	existingBook, err := h.Repository.Get(ctx.Request.Context(), objectId)
 	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
 		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
-	if result.DeletedCount == 0 {
-		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+
+	// Require the version the client last read
+	if present, matches := ifMatch(ctx, bookETag(existingBook.Version)); !present {
+		helpers.PreconditionRequired(ctx, http.StatusPreconditionRequired, constant.ErrorPreconditionRequired)
+		return
+	} else if !matches {
+		helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+		return
+	}
+
+	// a book comes off the shelf only once its copies are back, the trash purge deletes the idle ones
+	availability, err := h.Copies.Availability(ctx.Request.Context(), []primitive.ObjectID{objectId})
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if counts := availability[objectId]; counts.OnLoan > 0 || counts.OnHold > 0 {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictBookOnLoan)
+		return
+	}
+
+	deletedBook := existingBook
+	deletedBook.Version++
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Delete(txCtx, objectId, existingBook.Version); err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionDelete, existingBook.Book(), deletedBook)
+	})
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
 		return
 	}
 
 	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteBook, nil)
 }
+
+// GetTrashBookHandler godoc
+// @Summary Get deleted books
+// @Description Get a page of the books in the trash, most recently deleted first unless another sort is given
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Param after query string false "Cursor: return the books after this book ID, page is ignored"
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending (e.g. -deletedAt)"
+// @Success 200 {object} helpers.Response "Books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/trash [get]
+func (h *BooksController) GetTrashBookHandler(ctx *gin.Context) {
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	if err := h.expandGenres(ctx.Request.Context(), &opts.Filter); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	opts.Filter.Trashed = true
+	if len(opts.Sort) == 0 {
+		opts.Sort = []repository.SortField{{Field: "deletedAt", Descending: true}}
+	}
+
+	page, err := h.Repository.List(ctx.Request.Context(), opts)
+	if err != nil {
+		if err == repository.ErrInvalidCursor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	pagination := helpers.Pagination{
+		Limit:      opts.Limit,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	if opts.After == nil {
+		pagination.Page = opts.Page
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBook, page.Books, pagination)
+}
+
+// RestoreBookHandler godoc
+// @Summary Restore a deleted book
+// @Description Move a book out of the trash back into the library, unless another book took its ISBN meanwhile
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Success 200 {object} helpers.Response "Book restored successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book not found in trash"
+// @Failure 409 {object} helpers.Response "A book with this ISBN already exists"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/restore [post]
+func (h *BooksController) RestoreBookHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Restore(txCtx, objectId); err != nil {
+			return err
+		}
+		restoredBook, err := h.Repository.Get(txCtx, objectId)
+		if err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionRestore, restoredBook.Book(), restoredBook)
+	})
+	if err != nil {
+		if err == repository.ErrBookNotInTrash {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundTrashBook)
+			return
+		}
+		if err == repository.ErrDuplicateISBN {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictISBN)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessRestoreBook, nil)
+}
+
+// serverManagedFields are set by the server and ignored when they appear in a patch
+var serverManagedFields = []string{"id", "createdAt", "updatedAt", "originalIsbn"}
+
+// patchBook applies a patch to the editable fields of a book and decodes the result,
+// unknown fields and values of the wrong type are rejected
+func patchBook(existing entity.Books, patch []byte, applyPatch func(document []byte, patch []byte) ([]byte, error)) (entity.Book, error) {
+	var book entity.Book
+
+	document, err := json.Marshal(existing.Book())
+	if err != nil {
+		return book, err
+	}
+	patched, err := applyPatch(document, patch)
+	if err != nil {
+		return book, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patched, &fields); err != nil {
+		return book, err
+	}
+	for _, field := range serverManagedFields {
+		delete(fields, field)
+	}
+	patched, _ = json.Marshal(fields)
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&book)
+	return book, err
+}
+
+// keepServerFields normalizes the ISBN, stamps the update time and keeps the fields the client cannot change
+func keepServerFields(book *entity.Book, existing entity.Books) {
+	normalizeBookISBN(book)
+	if book.ISBN == existing.ISBN {
+		book.OriginalISBN = existing.OriginalISBN
+	}
+	book.CreatedAt = existing.CreatedAt
+	updatedAt := time.Now().UTC()
+	book.UpdatedAt = &updatedAt
+}
+
+// resolveReferences checks the genre, the work and the authors of a book before it is saved
+func (h *BooksController) resolveReferences(ctx context.Context, book *entity.Book) error {
+	if err := h.checkGenre(ctx, book); err != nil {
+		return err
+	}
+	if err := h.checkWork(ctx, book); err != nil {
+		return err
+	}
+	return h.linkAuthors(ctx, book)
+}
+
+// checkGenre stores the genre of a book as its slug and checks it is in the taxonomy, books may have no genre
+func (h *BooksController) checkGenre(ctx context.Context, book *entity.Book) error {
+	book.Genre = utils.GenreSlug(book.Genre)
+	if book.Genre == "" {
+		return nil
+	}
+
+	if _, err := h.Genres.Get(ctx, book.Genre); err != nil {
+		if err == repository.ErrGenreNotFound {
+			return errUnknownGenre
+		}
+		return err
+	}
+	return nil
+}
+
+// expandGenres widens the genre filter to the subgenres of the requested genre
+func (h *BooksController) expandGenres(ctx context.Context, filter *repository.BookFilter) error {
+	if len(filter.Genres) == 0 {
+		return nil
+	}
+
+	genres, err := h.Genres.List(ctx)
+	if err != nil {
+		return err
+	}
+	filter.Genres = services.GenreDescendants(genres, filter.Genres[0])
+	return nil
+}
+
+// linkAuthors checks the authors a book references exist. A book sent without author IDs is linked
+// to the author whose name or variant matches its author text, when exactly one does.
+func (h *BooksController) linkAuthors(ctx context.Context, book *entity.Book) error {
+	if len(book.AuthorIDs) > 0 {
+		exists, err := h.Authors.Exists(ctx, book.AuthorIDs)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errUnknownAuthor
+		}
+		return nil
+	}
+
+	authors, err := h.Authors.FindByName(ctx, book.Author)
+	if err != nil {
+		return err
+	}
+	if len(authors) == 1 {
+		book.AuthorIDs = []primitive.ObjectID{authors[0].ID}
+	}
+	return nil
+}
+
+// normalizeBookISBN stores the ISBN as hyphen-free ISBN-13 and keeps the value sent by the client
+func normalizeBookISBN(book *entity.Book) {
+	isbn, ok := utils.NormalizeISBN(book.ISBN)
+	if !ok {
+		book.OriginalISBN = ""
+		return
+	}
+	book.OriginalISBN = book.ISBN
+	book.ISBN = isbn
+}
+
+// searchLanguage picks the stemming language from the lang query or the Accept-Language header
+func searchLanguage(ctx *gin.Context) string {
+	accept := ctx.Query("lang")
+	if accept == "" {
+		accept = ctx.GetHeader("Accept-Language")
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English, language.Indonesian})
+	tag, _ := language.MatchStrings(matcher, accept)
+	if base, _ := tag.Base(); base.String() == services.LanguageIndonesian {
+		return services.LanguageIndonesian
+	}
+	return services.LanguageEnglish
+}
@@ -0,0 +1,460 @@
+package books
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"library-books/entity"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testResponse is the JSON envelope written by the helpers package
+type testResponse struct {
+	Code int             `json:"code"`
+	Data json.RawMessage `json:"data"`
+	Meta json.RawMessage `json:"meta"`
+}
+
+// newTestController returns a BooksController over empty memory repositories
+func newTestController(t *testing.T) *BooksController {
+	t.Helper()
+
+	validate := validator.New()
+	if err := utils.RegisterValidations(validate); err != nil {
+		t.Fatal(err)
+	}
+	return &BooksController{
+		Validate:   validate,
+		Repository: repository.NewMemoryBookRepository(),
+		Revisions:  repository.NewMemoryRevisionRepository(),
+		Transactor: repository.NewMemoryTransactor(),
+		Copies:     repository.NewMemoryCopyRepository(),
+		Authors:    repository.NewMemoryAuthorRepository(),
+		Genres:     repository.NewMemoryGenreRepository(),
+		Works:      repository.NewMemoryWorkRepository(),
+		Series:     repository.NewMemorySeriesRepository(),
+	}
+}
+
+// newTestRouter registers the book CRUD handlers of the controller the way BooksRoutes does
+func newTestRouter(h *BooksController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.NewLocalizerMiddleware().Middleware())
+	router.POST("/books", h.AddBookHandler)
+	router.GET("/books", h.GetAllBookHandler)
+	router.GET("/books/:id", h.GetBookHandler)
+	router.PUT("/books/:id", h.UpdateBookHandler)
+	router.DELETE("/books/:id", h.DeleteBookHandler)
+	router.POST("/books/:id/restore", h.RestoreBookHandler)
+	router.POST("/books/:id/revert/:revision", h.RevertBookHandler)
+	return router
+}
+
+// serve sends a request to the router, body is encoded as JSON unless nil
+func serve(t *testing.T, router *gin.Engine, method, target string, body any, headers map[string]string) (*httptest.ResponseRecorder, testResponse) {
+	t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := httptest.NewRequest(method, target, &reader)
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	var response testResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s %s: decode response %q: %v", method, target, recorder.Body.String(), err)
+	}
+	return recorder, response
+}
+
+// createTestBook stores a book directly in the repository of the controller
+func createTestBook(t *testing.T, h *BooksController, book entity.Book) primitive.ObjectID {
+	t.Helper()
+
+	id, err := h.Repository.Create(context.Background(), book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestAddBookHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		book map[string]any
+		code int
+	}{
+		{"valid", map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "isbn": "978-979-3062-79-2"}, http.StatusCreated},
+		{"without isbn", map[string]any{"title": "Bumi Manusia", "author": "Pramoedya Ananta Toer", "year": 1980}, http.StatusCreated},
+		{"missing title", map[string]any{"author": "Andrea Hirata", "year": 2005}, http.StatusBadRequest},
+		{"invalid isbn", map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "isbn": "978-979-3062-79-1"}, http.StatusBadRequest},
+		{"unknown genre", map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "genre": "fiction"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestController(t)
+			_, response := serve(t, newTestRouter(h), http.MethodPost, "/books", tt.book, nil)
+			if response.Code != tt.code {
+				t.Fatalf("code = %d, want %d", response.Code, tt.code)
+			}
+			if tt.code != http.StatusCreated {
+				return
+			}
+
+			page, err := h.Repository.List(context.Background(), repository.ListOptions{Page: 1, Limit: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != 1 || page.Books[0].Title != tt.book["title"] || page.Books[0].Version != 1 {
+				t.Fatalf("stored books = %+v", page.Books)
+			}
+			history, err := h.Revisions.List(context.Background(), page.Books[0].ID, 1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if history.Total != 1 || history.Revisions[0].Action != entity.RevisionCreate {
+				t.Fatalf("revisions = %+v", history.Revisions)
+			}
+		})
+	}
+}
+
+func TestAddBookHandlerDuplicateISBN(t *testing.T) {
+	h := newTestController(t)
+	router := newTestRouter(h)
+	book := map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "isbn": "9789793062792"}
+
+	if _, response := serve(t, router, http.MethodPost, "/books", book, nil); response.Code != http.StatusCreated {
+		t.Fatalf("first code = %d, want %d", response.Code, http.StatusCreated)
+	}
+	// the same ISBN written as hyphenated ISBN-10 is normalized to the stored ISBN-13
+	book["isbn"] = "979-3062-79-7"
+	if _, response := serve(t, router, http.MethodPost, "/books", book, nil); response.Code != http.StatusConflict {
+		t.Fatalf("second code = %d, want %d", response.Code, http.StatusConflict)
+	}
+}
+
+func TestGetBookHandler(t *testing.T) {
+	h := newTestController(t)
+	router := newTestRouter(h)
+	id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005})
+
+	recorder, response := serve(t, router, http.MethodGet, "/books/"+id.Hex(), nil, nil)
+	if response.Code != http.StatusOK {
+		t.Fatalf("code = %d, want %d", response.Code, http.StatusOK)
+	}
+	var book entity.Books
+	if err := json.Unmarshal(response.Data, &book); err != nil {
+		t.Fatal(err)
+	}
+	if book.ID != id || book.Title != "Laskar Pelangi" || book.Availability == nil {
+		t.Fatalf("book = %+v", book)
+	}
+	if etag := recorder.Header().Get("ETag"); etag == "" {
+		t.Fatal("missing ETag header")
+	}
+
+	for target, code := range map[string]int{
+		"/books/not-an-id":                        http.StatusBadRequest,
+		"/books/" + primitive.NewObjectID().Hex(): http.StatusNotFound,
+	} {
+		if _, response := serve(t, router, http.MethodGet, target, nil, nil); response.Code != code {
+			t.Errorf("GET %s code = %d, want %d", target, response.Code, code)
+		}
+	}
+}
+
+func TestGetAllBookHandler(t *testing.T) {
+	h := newTestController(t)
+	router := newTestRouter(h)
+	for _, book := range []entity.Book{
+		{Title: "Bumi Manusia", Author: "Pramoedya Ananta Toer", Year: 1980},
+		{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005},
+		{Title: "Sang Pemimpi", Author: "Andrea Hirata", Year: 2006},
+	} {
+		createTestBook(t, h, book)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		code   int
+		titles []string
+		total  int64
+	}{
+		{"all", "/books?sort=year", http.StatusOK, []string{"Bumi Manusia", "Laskar Pelangi", "Sang Pemimpi"}, 3},
+		{"descending", "/books?sort=-year&limit=2", http.StatusOK, []string{"Sang Pemimpi", "Laskar Pelangi"}, 3},
+		{"second page", "/books?sort=year&limit=2&page=2", http.StatusOK, []string{"Sang Pemimpi"}, 3},
+		{"author filter", "/books?author=hirata&sort=title", http.StatusOK, []string{"Laskar Pelangi", "Sang Pemimpi"}, 2},
+		{"year range", "/books?yearFrom=2000&yearTo=2005", http.StatusOK, []string{"Laskar Pelangi"}, 1},
+		{"unknown sort field", "/books?sort=price", http.StatusBadRequest, nil, 0},
+		{"limit too large", "/books?limit=1000", http.StatusBadRequest, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, response := serve(t, router, http.MethodGet, tt.target, nil, nil)
+			if response.Code != tt.code {
+				t.Fatalf("code = %d, want %d", response.Code, tt.code)
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			var books []entity.Books
+			if err := json.Unmarshal(response.Data, &books); err != nil {
+				t.Fatal(err)
+			}
+			titles := make([]string, len(books))
+			for i, book := range books {
+				titles[i] = book.Title
+			}
+			if len(titles) != len(tt.titles) {
+				t.Fatalf("titles = %v, want %v", titles, tt.titles)
+			}
+			for i := range titles {
+				if titles[i] != tt.titles[i] {
+					t.Fatalf("titles = %v, want %v", titles, tt.titles)
+				}
+			}
+
+			var meta struct {
+				Total int64 `json:"total"`
+			}
+			if err := json.Unmarshal(response.Meta, &meta); err != nil {
+				t.Fatal(err)
+			}
+			if meta.Total != tt.total {
+				t.Fatalf("total = %d, want %d", meta.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestUpdateBookHandler(t *testing.T) {
+	update := map[string]any{"title": "Laskar Pelangi (Edisi Revisi)", "author": "Andrea Hirata", "year": 2005}
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		body    map[string]any
+		code    int
+	}{
+		{"matching version", `"1"`, update, http.StatusOK},
+		{"read etag", `"1-0.0.0.0.0"`, update, http.StatusOK},
+		{"missing if-match", "", update, http.StatusPreconditionRequired},
+		{"stale version", `"2"`, update, http.StatusPreconditionFailed},
+		{"invalid body", `"1"`, map[string]any{"title": "No Author"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestController(t)
+			id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005})
+
+			headers := map[string]string{}
+			if tt.ifMatch != "" {
+				headers["If-Match"] = tt.ifMatch
+			}
+			recorder, response := serve(t, newTestRouter(h), http.MethodPut, "/books/"+id.Hex(), tt.body, headers)
+			if response.Code != tt.code {
+				t.Fatalf("code = %d, want %d", response.Code, tt.code)
+			}
+
+			book, err := h.Repository.Get(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.code != http.StatusOK {
+				if book.Version != 1 || book.Title != "Laskar Pelangi" {
+					t.Fatalf("rejected update changed the book: %+v", book)
+				}
+				return
+			}
+			if book.Version != 2 || book.Title != update["title"] || book.UpdatedAt == nil {
+				t.Fatalf("book = %+v", book)
+			}
+			if etag := recorder.Header().Get("ETag"); etag != `"2"` {
+				t.Fatalf("ETag = %s, want %q", etag, `"2"`)
+			}
+			history, err := h.Revisions.List(context.Background(), id, 1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if history.Total != 1 || history.Revisions[0].Revision != 2 || len(history.Revisions[0].Changes) == 0 {
+				t.Fatalf("revisions = %+v", history.Revisions)
+			}
+		})
+	}
+}
+
+func TestUpdateBookHandlerNotFound(t *testing.T) {
+	h := newTestController(t)
+	book := map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005}
+	_, response := serve(t, newTestRouter(h), http.MethodPut, "/books/"+primitive.NewObjectID().Hex(), book, map[string]string{"If-Match": `"1"`})
+	if response.Code != http.StatusNotFound {
+		t.Fatalf("code = %d, want %d", response.Code, http.StatusNotFound)
+	}
+}
+
+func TestRevertBookHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		removeGenre bool
+		code        int
+		wantVersion int64
+		wantGenre   string
+	}{
+		{"genre still in the taxonomy", false, http.StatusOK, 3, "fiction"},
+		{"genre removed since", true, http.StatusBadRequest, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestController(t)
+			router := newTestRouter(h)
+			if err := h.Genres.Create(context.Background(), entity.Genre{Slug: "fiction", Labels: map[string]string{"en": "Fiction"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			book := map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "genre": "fiction"}
+			if _, response := serve(t, router, http.MethodPost, "/books", book, nil); response.Code != http.StatusCreated {
+				t.Fatalf("create code = %d, want %d", response.Code, http.StatusCreated)
+			}
+			page, err := h.Repository.List(context.Background(), repository.ListOptions{Page: 1, Limit: 1})
+			if err != nil || len(page.Books) != 1 {
+				t.Fatalf("List() = %+v, %v", page, err)
+			}
+			id := page.Books[0].ID
+
+			delete(book, "genre")
+			if _, response := serve(t, router, http.MethodPut, "/books/"+id.Hex(), book, map[string]string{"If-Match": `"1"`}); response.Code != http.StatusOK {
+				t.Fatalf("update code = %d, want %d", response.Code, http.StatusOK)
+			}
+			if tt.removeGenre {
+				if err := h.Genres.Delete(context.Background(), "fiction"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, response := serve(t, router, http.MethodPost, "/books/"+id.Hex()+"/revert/1", nil, map[string]string{"If-Match": `"2"`})
+			if response.Code != tt.code {
+				t.Fatalf("code = %d, want %d", response.Code, tt.code)
+			}
+			stored, err := h.Repository.Get(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Version != tt.wantVersion || stored.Genre != tt.wantGenre {
+				t.Fatalf("book = %+v", stored)
+			}
+		})
+	}
+}
+
+func TestDeleteBookHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifMatch    string
+		copyStatus string
+		code       int
+	}{
+		{"matching version", `"1"`, "", http.StatusOK},
+		{"any version", "*", "", http.StatusOK},
+		{"missing if-match", "", "", http.StatusPreconditionRequired},
+		{"stale version", `"3"`, "", http.StatusPreconditionFailed},
+		{"copy available", "*", entity.CopyAvailable, http.StatusOK},
+		{"copy on loan", "*", entity.CopyOnLoan, http.StatusConflict},
+		{"copy on hold", "*", entity.CopyOnHold, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestController(t)
+			router := newTestRouter(h)
+			id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005})
+			if tt.copyStatus != "" {
+				if _, err := h.Copies.Create(context.Background(), id, entity.Copy{Barcode: "B0001", Status: tt.copyStatus}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			headers := map[string]string{}
+			if tt.ifMatch != "" {
+				headers["If-Match"] = tt.ifMatch
+			}
+			_, response := serve(t, router, http.MethodDelete, "/books/"+id.Hex(), nil, headers)
+			if response.Code != tt.code {
+				t.Fatalf("code = %d, want %d", response.Code, tt.code)
+			}
+
+			// a deleted book is hidden from reads and moved to the trash
+			_, response = serve(t, router, http.MethodGet, "/books/"+id.Hex(), nil, nil)
+			deleted := response.Code == http.StatusNotFound
+			if deleted != (tt.code == http.StatusOK) {
+				t.Fatalf("GET after delete code = %d", response.Code)
+			}
+			if !deleted {
+				return
+			}
+			trash, err := h.Repository.List(context.Background(), repository.ListOptions{Filter: repository.BookFilter{Trashed: true}, Page: 1, Limit: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if trash.Total != 1 || trash.Books[0].ID != id || trash.Books[0].DeletedAt == nil {
+				t.Fatalf("trash = %+v", trash.Books)
+			}
+		})
+	}
+}
+
+func TestDeleteBookHandlerNotFound(t *testing.T) {
+	h := newTestController(t)
+	_, response := serve(t, newTestRouter(h), http.MethodDelete, "/books/"+primitive.NewObjectID().Hex(), nil, map[string]string{"If-Match": "*"})
+	if response.Code != http.StatusNotFound {
+		t.Fatalf("code = %d, want %d", response.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeletedBookISBN(t *testing.T) {
+	h := newTestController(t)
+	router := newTestRouter(h)
+	id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005, ISBN: "9789793062792"})
+	if _, response := serve(t, router, http.MethodDelete, "/books/"+id.Hex(), nil, map[string]string{"If-Match": "*"}); response.Code != http.StatusOK {
+		t.Fatalf("delete code = %d, want %d", response.Code, http.StatusOK)
+	}
+
+	// the ISBN of a book in the trash can be used again
+	book := map[string]any{"title": "Laskar Pelangi", "author": "Andrea Hirata", "year": 2005, "isbn": "9789793062792"}
+	if _, response := serve(t, router, http.MethodPost, "/books", book, nil); response.Code != http.StatusCreated {
+		t.Fatalf("create code = %d, want %d", response.Code, http.StatusCreated)
+	}
+
+	// and the trashed book cannot come back while another book has it
+	if _, response := serve(t, router, http.MethodPost, "/books/"+id.Hex()+"/restore", nil, nil); response.Code != http.StatusConflict {
+		t.Fatalf("restore code = %d, want %d", response.Code, http.StatusConflict)
+	}
+	if _, err := h.Repository.Get(context.Background(), id); err != repository.ErrBookNotFound {
+		t.Fatalf("Get() error = %v, want %v", err, repository.ErrBookNotFound)
+	}
+}
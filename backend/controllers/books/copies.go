@@ -0,0 +1,331 @@
+package books
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetCopiesHandler godoc
+// @Summary Get the copies of a book
+// @Description Get every physical copy of a book ordered by barcode
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} helpers.Response{data=[]entity.Copies} "Copies retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/copies [get]
+func (h *BooksController) GetCopiesHandler(ctx *gin.Context) {
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	copies, err := h.Copies.List(ctx.Request.Context(), bookId)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetCopy, copies)
+}
+
+// AddCopyHandler godoc
+// @Summary Add a copy of a book
+// @Description Register a physical copy of a book, the status defaults to available and cannot be on_loan or on_hold. An available copy is set aside for the next hold on the book.
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param copy body entity.Copy true "Copy data"
+// @Success 201 {object} helpers.Response{data=entity.Copies} "Copy added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "A copy with this barcode already exists or status is on_loan or on_hold"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/copies [post]
+func (h *BooksController) AddCopyHandler(ctx *gin.Context) {
+	var copy entity.Copy
+	if err := ctx.ShouldBindJSON(&copy); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	copy.Barcode = strings.TrimSpace(copy.Barcode)
+	copy.Material = strings.ToLower(strings.TrimSpace(copy.Material))
+	if copy.Status == "" {
+		copy.Status = entity.CopyAvailable
+	}
+	if entity.InCirculation(copy.Status) {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictCopyStatus)
+		return
+	}
+
+	// Validate input
+	if err := h.Validate.Struct(copy); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := h.Copies.Create(ctx.Request.Context(), bookId, copy)
+	if err != nil {
+		if err == repository.ErrDuplicateBarcode {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictBarcode)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if copy.Status == entity.CopyAvailable {
+		h.allocateCopy(ctx.Request.Context(), id)
+	}
+
+	created, err := h.Copies.Get(ctx.Request.Context(), bookId, id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddCopy, created)
+}
+
+// GetCopyHandler godoc
+// @Summary Get a copy of a book
+// @Description Get a single physical copy of a book by its ID
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param copyId path string true "Copy ID"
+// @Success 200 {object} helpers.Response{data=entity.Copies} "Copy retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book or copy not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/copies/{copyId} [get]
+func (h *BooksController) GetCopyHandler(ctx *gin.Context) {
+	copyId, err := primitive.ObjectIDFromHex(ctx.Param("copyId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	copy, err := h.Copies.Get(ctx.Request.Context(), bookId, copyId)
+	if err != nil {
+		if err == repository.ErrCopyNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCopy)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetCopy, copy)
+}
+
+// UpdateCopyHandler godoc
+// @Summary Update a copy of a book
+// @Description Replace the barcode, location, status, acquisition date and price of a copy, an empty status keeps the current one. The on_loan and on_hold statuses are managed by loans and holds, a copy made available is set aside for the next hold on the book.
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param copyId path string true "Copy ID"
+// @Param copy body entity.Copy true "Copy data"
+// @Success 200 {object} helpers.Response{data=entity.Copies} "Copy updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book or copy not found"
+// @Failure 409 {object} helpers.Response "A copy with this barcode already exists, the on_loan or on_hold status changed or the status changed since the copy was read"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/copies/{copyId} [put]
+func (h *BooksController) UpdateCopyHandler(ctx *gin.Context) {
+	copyId, err := primitive.ObjectIDFromHex(ctx.Param("copyId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	var copy entity.Copy
+	if err := ctx.ShouldBindJSON(&copy); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	copy.Barcode = strings.TrimSpace(copy.Barcode)
+	copy.Material = strings.ToLower(strings.TrimSpace(copy.Material))
+
+	// Validate input
+	if err := h.Validate.Struct(copy); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	existingCopy, err := h.Copies.Get(ctx.Request.Context(), bookId, copyId)
+	if err != nil {
+		if err == repository.ErrCopyNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCopy)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if copy.Status == "" {
+		copy.Status = existingCopy.Status
+	}
+
+	// a copy goes on and off loan or hold only through checkout, return and the hold queue
+	if copy.Status != existingCopy.Status && (entity.InCirculation(copy.Status) || entity.InCirculation(existingCopy.Status)) {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictCopyStatus)
+		return
+	}
+
+	err = h.Copies.Update(ctx.Request.Context(), bookId, copyId, existingCopy.Status, copy)
+	if err != nil {
+		if err == repository.ErrCopyNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCopy)
+			return
+		}
+		if err == repository.ErrCopyStatusChanged {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictCopyChanged)
+			return
+		}
+		if err == repository.ErrDuplicateBarcode {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictBarcode)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if copy.Status == entity.CopyAvailable && existingCopy.Status != entity.CopyAvailable {
+		h.allocateCopy(ctx.Request.Context(), copyId)
+	}
+
+	updated, err := h.Copies.Get(ctx.Request.Context(), bookId, copyId)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateCopy, updated)
+}
+
+// DeleteCopyHandler godoc
+// @Summary Delete a copy of a book
+// @Description Remove a physical copy from the inventory, copies on loan or held for a patron cannot be deleted
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param copyId path string true "Copy ID"
+// @Success 200 {object} helpers.Response "Copy deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book or copy not found"
+// @Failure 409 {object} helpers.Response "Copy is on loan or on hold"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/copies/{copyId} [delete]
+func (h *BooksController) DeleteCopyHandler(ctx *gin.Context) {
+	copyId, err := primitive.ObjectIDFromHex(ctx.Param("copyId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	err = h.Copies.Delete(ctx.Request.Context(), bookId, copyId)
+	if err != nil {
+		if err == repository.ErrCopyNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCopy)
+			return
+		}
+		if err == repository.ErrCopyOnLoan {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictCopyOnLoan)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteCopy, nil)
+}
+
+// copyBook parses the book ID of a copy route and checks the book exists,
+// the error response is already written when ok is false
+func (h *BooksController) copyBook(ctx *gin.Context) (bookId primitive.ObjectID, ok bool) {
+	bookId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return bookId, false
+	}
+
+	if _, err := h.Repository.Get(ctx.Request.Context(), bookId); err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return bookId, false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return bookId, false
+	}
+	return bookId, true
+}
+
+// allocateCopy sets a copy that came on the shelf aside for the next hold on its book.
+// The copy is already saved at this point, so a failure is logged and the copy stays available.
+func (h *BooksController) allocateCopy(ctx context.Context, copyId primitive.ObjectID) {
+	now := time.Now().UTC()
+	if err := h.Holds.Allocate(ctx, copyId, now, now.Add(h.PickupWindow)); err != nil {
+		log.Println("allocate copy to hold:", err)
+	}
+}
+
+// setAvailability fills the copy counts of the given books, books without copies report zero copies
+func (h *BooksController) setAvailability(ctx context.Context, books []*entity.Books) error {
+	ids := make([]primitive.ObjectID, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+
+	availability, err := h.Copies.Availability(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, book := range books {
+		counts := availability[book.ID]
+		book.Availability = &counts
+	}
+	return nil
+}
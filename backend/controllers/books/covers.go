@@ -0,0 +1,226 @@
+package books
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// multipartOverhead is the room left in the request body for the multipart headers around the uploaded cover
+const multipartOverhead = 1 << 20
+
+// CoverPolicy limits the uploaded covers and sets where they are served from
+type CoverPolicy struct {
+	// MaxSize is the largest accepted cover file in bytes
+	MaxSize int64
+	// BaseURL prefixes the URLs of the served covers, such as "https://library.example.com", empty keeps them relative
+	BaseURL string
+}
+
+// UploadCoverHandler godoc
+// @Summary Upload the cover of a book
+// @Description Upload a JPEG, PNG, GIF or WebP cover image as the file field of a multipart form. The type is sniffed from the file content. The image is stored with small, medium and large JPEG thumbnails, and the coverImageUrl of the book is set to the URL the cover is served from. Every upload gets its own URL, a new upload replaces the previous cover, which is deleted once the book points to the new one. If-Match is optional, when sent it must match the current version of the book.
+// @Tags Books
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param If-Match header string false "ETag of the book version being changed"
+// @Param file formData file true "Cover image"
+// @Success 200 {object} helpers.Response{data=entity.Cover} "Cover uploaded successfully"
+// @Failure 400 {object} helpers.Response "Invalid input or unreadable image"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 412 {object} helpers.Response "Book changed since the given version"
+// @Failure 413 {object} helpers.Response "Cover file too large"
+// @Failure 415 {object} helpers.Response "Cover is not a JPEG, PNG, GIF or WebP image"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/cover [post]
+func (h *BooksController) UploadCoverHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	existingBook, err := h.Repository.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if present, matches := ifMatch(ctx, bookETag(existingBook.Version)); present && !matches {
+		helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+		return
+	}
+
+	data, ok := h.readCover(ctx)
+	if !ok {
+		return
+	}
+	img, contentType, err := services.DecodeCover(data)
+	if err != nil {
+		if err == services.ErrUnsupportedCover {
+			helpers.UnsupportedMediaType(ctx, http.StatusUnsupportedMediaType, constant.ErrorUnsupportedCover)
+			return
+		}
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidCover)
+		return
+	}
+
+	// every upload is stored under its own keys, the current cover is untouched until the book points to the new one
+	uploadId := primitive.NewObjectID().Hex()
+	cover := entity.Cover{
+		URL:         h.coverURL(objectId, uploadId, ""),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Thumbnails:  map[string]string{},
+	}
+	if err := h.storeCover(ctx.Request.Context(), objectId, uploadId, data, contentType, img); err != nil {
+		h.deleteCover(ctx.Request.Context(), objectId, uploadId)
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	for _, thumbnail := range services.CoverThumbnails {
+		cover.Thumbnails[thumbnail.Name] = h.coverURL(objectId, uploadId, thumbnail.Name)
+	}
+
+	book := existingBook.Book()
+	book.CoverImageUrl = cover.URL
+	keepServerFields(&book, existingBook)
+	updatedBook := book.Books(objectId)
+	updatedBook.Version = existingBook.Version + 1
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Update(txCtx, objectId, book, existingBook.Version); err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionUpdate, existingBook.Book(), updatedBook)
+	})
+	if err != nil {
+		// the book still points to its previous cover, the new upload is never served
+		h.deleteCover(ctx.Request.Context(), objectId, uploadId)
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// the previous cover and its resized variants are never served again, they only take disk space
+	if previous, ok := services.CoverUploadID(objectId.Hex(), existingBook.CoverImageUrl); ok {
+		h.deleteCover(ctx.Request.Context(), objectId, previous)
+	}
+
+	ctx.Header("ETag", bookETag(updatedBook.Version))
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUploadCover, cover)
+}
+
+// readCover reads the file field of the multipart request within the size limit of the cover policy,
+// the error response is already written when ok is false
+func (h *BooksController) readCover(ctx *gin.Context) (data []byte, ok bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.CoverPolicy.MaxSize+multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			helpers.PayloadTooLarge(ctx, http.StatusRequestEntityTooLarge, constant.ErrorCoverTooLarge)
+			return nil, false
+		}
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return nil, false
+	}
+	if fileHeader.Size > h.CoverPolicy.MaxSize {
+		helpers.PayloadTooLarge(ctx, http.StatusRequestEntityTooLarge, constant.ErrorCoverTooLarge)
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err = io.ReadAll(io.LimitReader(file, h.CoverPolicy.MaxSize+1))
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return nil, false
+	}
+	if int64(len(data)) > h.CoverPolicy.MaxSize {
+		helpers.PayloadTooLarge(ctx, http.StatusRequestEntityTooLarge, constant.ErrorCoverTooLarge)
+		return nil, false
+	}
+	return data, true
+}
+
+// storeCover stores an uploaded cover under the keys of the upload, the thumbnails first so a stored cover
+// always has its thumbnails
+func (h *BooksController) storeCover(ctx context.Context, bookId primitive.ObjectID, uploadId string, data []byte, contentType string, img image.Image) error {
+	for _, thumbnail := range services.CoverThumbnails {
+		var encoded bytes.Buffer
+		if err := services.EncodeCoverJPEG(&encoded, services.ResizeCover(img, thumbnail.Width, 0)); err != nil {
+			return err
+		}
+		if err := h.Covers.Put(ctx, services.CoverKey(bookId.Hex(), uploadId, thumbnail.Name), &encoded, "image/jpeg"); err != nil {
+			return err
+		}
+	}
+	return h.Covers.Put(ctx, services.CoverKey(bookId.Hex(), uploadId, ""), bytes.NewReader(data), contentType)
+}
+
+// deleteCover removes the blobs and the resized variants of a cover upload, failures only leave unused files behind
+func (h *BooksController) deleteCover(ctx context.Context, bookId primitive.ObjectID, uploadId string) {
+	var err error
+	if uploadId != "" {
+		err = h.Covers.DeletePrefix(ctx, services.CoverUploadPrefix(bookId.Hex(), uploadId))
+	} else {
+		// covers uploaded before per-upload keys share the prefix of the book with the newer uploads
+		err = h.Covers.Delete(ctx, services.CoverKey(bookId.Hex(), "", ""))
+		for _, thumbnail := range services.CoverThumbnails {
+			if err == nil {
+				err = h.Covers.Delete(ctx, services.CoverKey(bookId.Hex(), "", thumbnail.Name))
+			}
+		}
+	}
+	if err != nil {
+		log.Println("delete cover:", err)
+	}
+
+	// the variants of an old cover are only served until the book points elsewhere, dropping a few of the new one only costs a resize
+	prefix := services.CoverUploadPrefix(bookId.Hex(), uploadId)
+	if err := h.CoverCache.DeletePrefix(ctx, prefix); err != nil {
+		log.Println("clear resized covers:", err)
+	}
+}
+
+// coverURL is the URL a cover upload of a book is served from, or one of its thumbnails when thumbnail is not empty
+func (h *BooksController) coverURL(bookId primitive.ObjectID, uploadId string, thumbnail string) string {
+	url := h.CoverPolicy.BaseURL + "/media/covers/" + bookId.Hex() + "/" + uploadId
+	if thumbnail != "" {
+		url += "?size=" + thumbnail
+	}
+	return url
+}
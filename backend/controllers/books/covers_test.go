@@ -0,0 +1,137 @@
+package books
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"library-books/entity"
+	"library-books/services"
+	"library-books/storage"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newCoverTestController returns a BooksController storing covers in temporary directories
+func newCoverTestController(t *testing.T) *BooksController {
+	t.Helper()
+
+	h := newTestController(t)
+	var err error
+	if h.Covers, err = storage.NewLocalBlobStore(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if h.CoverCache, err = storage.NewLocalBlobStore(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	h.CoverPolicy = CoverPolicy{MaxSize: 1 << 20}
+	return h
+}
+
+// uploadCover posts a small PNG cover for the book
+func uploadCover(t *testing.T, h *BooksController, id primitive.ObjectID, ifMatch string) (int, entity.Cover) {
+	t.Helper()
+
+	var file bytes.Buffer
+	if err := png.Encode(&file, image.NewRGBA(image.Rect(0, 0, 20, 30))); err != nil {
+		t.Fatal(err)
+	}
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "cover.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(file.Bytes())
+	form.Close()
+
+	router := newTestRouter(h)
+	router.POST("/books/:id/cover", h.UploadCoverHandler)
+	request := httptest.NewRequest(http.MethodPost, "/books/"+id.Hex()+"/cover", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	if ifMatch != "" {
+		request.Header.Set("If-Match", ifMatch)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	var response struct {
+		Code int          `json:"code"`
+		Data entity.Cover `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response %q: %v", recorder.Body.String(), err)
+	}
+	return response.Code, response.Data
+}
+
+// storedCover reports whether the original of a cover upload is in the store
+func storedCover(t *testing.T, h *BooksController, id primitive.ObjectID, uploadId string) bool {
+	t.Helper()
+
+	content, _, err := h.Covers.Get(context.Background(), services.CoverKey(id.Hex(), uploadId, ""))
+	if err == storage.ErrBlobNotFound {
+		return false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	content.Close()
+	return true
+}
+
+func TestUploadCoverHandler(t *testing.T) {
+	h := newCoverTestController(t)
+	id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005})
+
+	code, first := uploadCover(t, h, id, "")
+	if code != http.StatusOK {
+		t.Fatalf("first upload code = %d, want %d", code, http.StatusOK)
+	}
+	firstUpload, ok := services.CoverUploadID(id.Hex(), first.URL)
+	if !ok || firstUpload == "" || !storedCover(t, h, id, firstUpload) {
+		t.Fatalf("first upload %q is not stored", first.URL)
+	}
+
+	code, second := uploadCover(t, h, id, "")
+	if code != http.StatusOK {
+		t.Fatalf("second upload code = %d, want %d", code, http.StatusOK)
+	}
+	secondUpload, _ := services.CoverUploadID(id.Hex(), second.URL)
+	if secondUpload == firstUpload || !storedCover(t, h, id, secondUpload) {
+		t.Fatalf("second upload %q is not stored under its own key", second.URL)
+	}
+	if storedCover(t, h, id, firstUpload) {
+		t.Fatal("replaced cover was not deleted")
+	}
+
+	book, err := h.Repository.Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.CoverImageUrl != second.URL || book.Version != 3 {
+		t.Fatalf("book = %+v", book)
+	}
+}
+
+func TestUploadCoverHandlerStaleVersion(t *testing.T) {
+	h := newCoverTestController(t)
+	id := createTestBook(t, h, entity.Book{Title: "Laskar Pelangi", Author: "Andrea Hirata", Year: 2005})
+	code, cover := uploadCover(t, h, id, "")
+	if code != http.StatusOK {
+		t.Fatalf("upload code = %d, want %d", code, http.StatusOK)
+	}
+	upload, _ := services.CoverUploadID(id.Hex(), cover.URL)
+
+	if code, _ := uploadCover(t, h, id, `"1"`); code != http.StatusPreconditionFailed {
+		t.Fatalf("stale upload code = %d, want %d", code, http.StatusPreconditionFailed)
+	}
+	if !storedCover(t, h, id, upload) {
+		t.Fatal("current cover was deleted by a rejected upload")
+	}
+}
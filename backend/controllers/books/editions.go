@@ -0,0 +1,123 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errUnknownWork is returned by checkWork when a book references a work that does not exist
+var errUnknownWork = errors.New("unknown work id")
+
+// checkWork checks the work of an edition exists, books may have no work
+func (h *BooksController) checkWork(ctx context.Context, book *entity.Book) error {
+	if book.WorkID == nil {
+		return nil
+	}
+
+	if _, err := h.Works.Get(ctx, *book.WorkID); err != nil {
+		if err == repository.ErrWorkNotFound {
+			return errUnknownWork
+		}
+		return err
+	}
+	return nil
+}
+
+// setEditions fills the other editions of the work of a book and the works that follow it in its series
+func (h *BooksController) setEditions(ctx context.Context, book *entity.Books) error {
+	if book.WorkID == nil {
+		return nil
+	}
+
+	editions, err := h.editions(ctx, *book.WorkID)
+	if err != nil {
+		return err
+	}
+	for _, edition := range editions {
+		if edition.ID != book.ID {
+			book.OtherEditions = append(book.OtherEditions, edition)
+		}
+	}
+
+	work, err := h.Works.Get(ctx, *book.WorkID)
+	if err == repository.ErrWorkNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range work.Series {
+		next, err := h.nextInSeries(ctx, entry, book.Language)
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			book.NextInSeries = append(book.NextInSeries, *next)
+		}
+	}
+	return nil
+}
+
+// nextInSeries finds the first work after the position of the entry in its series, nil when the series ends there
+func (h *BooksController) nextInSeries(ctx context.Context, entry entity.SeriesEntry, language string) (*entity.SeriesNext, error) {
+	series, err := h.Series.Get(ctx, entry.SeriesID)
+	if err == repository.ErrSeriesNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	works, err := h.Works.ListBySeries(ctx, entry.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	for _, work := range works {
+		position, _ := work.Position(entry.SeriesID)
+		if position <= entry.Position {
+			continue
+		}
+
+		next := &entity.SeriesNext{
+			SeriesID: series.ID,
+			Series:   series.Title,
+			Position: position,
+			WorkID:   work.ID,
+			Title:    work.Title,
+		}
+		editions, err := h.editions(ctx, work.ID)
+		if err != nil {
+			return nil, err
+		}
+		// prefer an edition in the language of the book the reader has, the oldest edition otherwise
+		if len(editions) > 0 {
+			pick := editions[0]
+			for _, edition := range editions {
+				if edition.Language == language {
+					pick = edition
+					break
+				}
+			}
+			next.BookID = &pick.ID
+		}
+		return next, nil
+	}
+	return nil, nil
+}
+
+// editions lists the editions of a work, oldest first
+func (h *BooksController) editions(ctx context.Context, workID primitive.ObjectID) ([]entity.Books, error) {
+	page, err := h.Repository.List(ctx, repository.ListOptions{
+		Filter: repository.BookFilter{WorkID: workID},
+		Sort:   []repository.SortField{{Field: "year"}},
+		Page:   1,
+		Limit:  helpers.MaxPageLimit,
+	})
+	return page.Books, err
+}
@@ -0,0 +1,78 @@
+package books
+
+import (
+	"fmt"
+	"hash/fnv"
+	"library-books/entity"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bookETag formats a book version as a strong entity tag
+func bookETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// bookReadETag tags a book as returned by the read endpoints. Copy availability, ratings, other editions
+// and the next works of its series change without a new book version, so the counts and a hash of the
+// rating and related books follow the version after a dash, ifMatch only compares the version.
+func bookReadETag(book entity.Books) string {
+	if book.Availability == nil {
+		return bookETag(book.Version)
+	}
+	counts := book.Availability
+	tag := fmt.Sprintf(`%d-%d.%d.%d.%d.%d`, book.Version, counts.Available, counts.OnLoan, counts.OnHold, counts.InRepair, counts.Lost)
+	if book.Rating != nil || len(book.OtherEditions) > 0 || len(book.NextInSeries) > 0 {
+		related := fnv.New32a()
+		if book.Rating != nil {
+			fmt.Fprintf(related, "rating:%d:%v;", book.Rating.Count, book.Rating.Histogram)
+		}
+		for _, edition := range book.OtherEditions {
+			fmt.Fprintf(related, "%s:%d;", edition.ID.Hex(), edition.Version)
+		}
+		for _, next := range book.NextInSeries {
+			bookID := ""
+			if next.BookID != nil {
+				bookID = next.BookID.Hex()
+			}
+			fmt.Fprintf(related, "%s:%s:%g:%s:%s:%s;", next.SeriesID.Hex(), next.Series, next.Position, next.WorkID.Hex(), next.Title, bookID)
+		}
+		tag += fmt.Sprintf("-%08x", related.Sum32())
+	}
+	return `"` + tag + `"`
+}
+
+// ifMatch reports whether the If-Match header is present and whether it matches the entity tag,
+// weak tags never match because If-Match uses the strong comparison. A tag from bookReadETag
+// matches the version tag it starts with.
+func ifMatch(ctx *gin.Context, etag string) (present bool, matches bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		return false, false
+	}
+	if header == "*" {
+		return true, true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || strings.HasPrefix(candidate, strings.TrimSuffix(etag, `"`)+"-") {
+			return true, true
+		}
+	}
+	return true, false
+}
+
+// setLastModified sets the Last-Modified header from the latest update of the book
+func setLastModified(ctx *gin.Context, book entity.Books) {
+	modified := book.CreatedAt
+	if book.UpdatedAt != nil {
+		modified = *book.UpdatedAt
+	}
+	if !modified.IsZero() {
+		ctx.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,82 @@
+package books
+
+import (
+	"fmt"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/services"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportBooksHandler godoc
+// @Summary Export books
+// @Description Download the catalog as CSV, NDJSON or a JSON array. The books are streamed from the database and accept the same filters and sort as the book list.
+// @Tags Books
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce json
+// @Param format query string false "Export format: csv, ndjson or json (default json)"
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending (e.g. year,-title)"
+// @Param author query string false "Filter by author (partial, case insensitive)"
+// @Param authorId query string false "Filter by linked author ID"
+// @Param workId query string false "Filter by work ID, the editions of a work"
+// @Param genre query string false "Filter by genre slug, books of its subgenres included"
+// @Param isbn query string false "Filter by ISBN"
+// @Param yearFrom query int false "Filter books published in or after this year"
+// @Param yearTo query int false "Filter books published in or before this year"
+// @Param createdAfter query string false "Filter books created after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param updatedBefore query string false "Filter books last updated before this time (RFC 3339 or YYYY-MM-DD)"
+// @Success 200 {file} file "Exported books"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Router /books/export [get]
+func (h *BooksController) ExportBooksHandler(ctx *gin.Context) {
+	format := strings.ToLower(ctx.DefaultQuery("format", services.ExportFormatJSON))
+	contentType, ok := services.ExportContentTypes[format]
+	if !ok {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	filter, err := parseBookFilter(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	if err := h.expandGenres(ctx.Request.Context(), &filter); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	sort, err := parseSort(ctx.Query("sort"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	writer, err := services.NewBookWriter(format, ctx.Writer)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	filename := fmt.Sprintf("books-%s.%s", time.Now().Format("20060102-150405"), format)
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Status(http.StatusOK)
+
+	// the status is already sent while streaming, so errors can only end the download early
+	err = h.Repository.Stream(ctx.Request.Context(), filter, sort, func(book entity.Books) error {
+		return writer.Write(book)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		log.Println("export books:", err)
+	}
+}
@@ -0,0 +1,195 @@
+package books
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetBookHistoryHandler godoc
+// @Summary Get the revision history of a book
+// @Description Get a page of the changes made to a book, newest first. Every revision has the acting user, the time, a field-level diff and the saved book.
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.BookRevision} "Book history retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/history [get]
+func (h *BooksController) GetBookHistoryHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	history, err := h.Revisions.List(ctx.Request.Context(), objectId, page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBookHistory, history.Revisions, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: history.Total,
+	})
+}
+
+// RevertBookHandler godoc
+// @Summary Revert a book to an earlier revision
+// @Description Save the book fields of the given revision as a new version of the book, the revert itself is recorded as a new revision
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param revision path int true "Revision number"
+// @Param If-Match header string true "ETag of the book version being changed"
+// @Success 200 {object} helpers.Response{data=entity.Books} "Book reverted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Book or revision not found"
+// @Failure 409 {object} helpers.Response "A book with this ISBN already exists"
+// @Failure 412 {object} helpers.Response "Book changed since the given version"
+// @Failure 428 {object} helpers.Response "If-Match header missing"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/revert/{revision} [post]
+func (h *BooksController) RevertBookHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	revisionNumber, err := strconv.ParseInt(ctx.Param("revision"), 10, 64)
+	if err != nil || revisionNumber < 1 {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	// Fetch existing book
+	existingBook, err := h.Repository.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// Require the version the client last read
+	if present, matches := ifMatch(ctx, bookETag(existingBook.Version)); !present {
+		helpers.PreconditionRequired(ctx, http.StatusPreconditionRequired, constant.ErrorPreconditionRequired)
+		return
+	} else if !matches {
+		helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+		return
+	}
+
+	revision, err := h.Revisions.Get(ctx.Request.Context(), objectId, revisionNumber)
+	if err != nil {
+		if err == repository.ErrRevisionNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundRevision)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// the saved fields are already normalized, only the timestamps are the server's
+	book := revision.Book
+	book.CreatedAt = existingBook.CreatedAt
+	updatedAt := time.Now().UTC()
+	book.UpdatedAt = &updatedAt
+
+	// the genres, authors and works of the revision may have been removed since
+	if err := h.resolveReferences(ctx.Request.Context(), &book); err != nil {
+		if err == errUnknownGenre {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+			return
+		}
+		if err == errUnknownAuthor {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownAuthor)
+			return
+		}
+		if err == errUnknownWork {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	var revertedBook entity.Books
+	err = h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		if err := h.Repository.Update(txCtx, objectId, book, existingBook.Version); err != nil {
+			return err
+		}
+		var err error
+		if revertedBook, err = h.Repository.Get(txCtx, objectId); err != nil {
+			return err
+		}
+		return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionRevert, existingBook.Book(), revertedBook)
+	})
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		if err == repository.ErrDuplicateISBN {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictISBN)
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			helpers.PreconditionFailed(ctx, http.StatusPreconditionFailed, constant.ErrorPreconditionFailed)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	if err := h.setAvailability(ctx.Request.Context(), []*entity.Books{&revertedBook}); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	ctx.Header("ETag", bookReadETag(revertedBook))
+	helpers.Success(ctx, http.StatusOK, constant.SuccessRevertBook, revertedBook)
+}
+
+// recordRevision appends the change that produced the given book version to its history made by userID.
+// It runs in the transaction of the change, so a change is never saved without its revision.
+func (h *BooksController) recordRevision(ctx context.Context, userID string, action string, before entity.Book, after entity.Books) error {
+	return h.Revisions.Create(ctx, entity.BookRevision{
+		BookID:    after.ID,
+		Revision:  after.Version,
+		Action:    action,
+		UserID:    userID,
+		CreatedAt: time.Now().UTC(),
+		Changes:   services.DiffBooks(before, after.Book()),
+		Book:      after.Book(),
+	})
+}
@@ -0,0 +1,210 @@
+package books
+
+import (
+	"context"
+	"io"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const importBatchSize = 500
+
+// ImportBooksHandler godoc
+// @Summary Import books
+// @Description Bulk import books from a CSV file (header row with title, author, year, isbn, genre, description, coverImageUrl) or NDJSON file (one book per line). Every row is validated and reported as accepted, rejected or duplicate. With dryRun the file is only validated.
+// @Tags Books
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format query string false "File format (csv or ndjson), detected from the file name when empty"
+// @Param dryRun query bool false "Validate without saving"
+// @Success 200 {object} helpers.Response{data=entity.ImportReport} "Books imported successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/import [post]
+func (h *BooksController) ImportBooksHandler(ctx *gin.Context) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	defer file.Close()
+
+	reader, err := services.NewBookRowReader(importFormat(ctx.Query("format"), fileHeader), file)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorImportFile)
+		return
+	}
+
+	report := entity.ImportReport{DryRun: dryRun, Rows: []entity.ImportRow{}}
+	seen := map[string]bool{}
+	var batch []int
+	var books []entity.Book
+
+	// flush checks the batch against stored ISBNs and inserts the remaining books
+	flush := func() error {
+		defer func() { batch, books = nil, nil }()
+
+		isbns := []string{}
+		for _, book := range books {
+			if book.ISBN != "" {
+				isbns = append(isbns, book.ISBN)
+			}
+		}
+		existing, err := h.Repository.ExistingISBNs(ctx.Request.Context(), isbns)
+		if err != nil {
+			return err
+		}
+
+		var pendingRows []int
+		var pendingBooks []entity.Book
+		for i, book := range books {
+			if existing[book.ISBN] {
+				report.Rows[batch[i]].Status = entity.ImportDuplicate
+				continue
+			}
+			pendingRows = append(pendingRows, batch[i])
+			pendingBooks = append(pendingBooks, book)
+		}
+		if dryRun {
+			return nil
+		}
+
+		// every book is saved with its revision in its own transaction, so an ISBN taken
+		// by a concurrent request aborts only that row and reports it as a duplicate
+		for i, book := range pendingBooks {
+			err := h.Transactor.Transaction(ctx.Request.Context(), func(txCtx context.Context) error {
+				id, err := h.Repository.Create(txCtx, book)
+				if err != nil {
+					return err
+				}
+				created := book.Books(id)
+				created.Version = 1
+				return h.recordRevision(txCtx, middleware.UserID(ctx), entity.RevisionCreate, entity.Book{}, created)
+			})
+			if err == repository.ErrDuplicateISBN {
+				report.Rows[pendingRows[i]].Status = entity.ImportDuplicate
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorImportFile)
+			return
+		}
+
+		item := entity.ImportRow{Line: row.Line, Status: entity.ImportAccepted, ISBN: row.Book.ISBN}
+		if row.Err == nil {
+			row.Err = h.Validate.Struct(row.Book)
+		}
+		if row.Err != nil {
+			item.Status, item.Error = entity.ImportRejected, row.Err.Error()
+			report.Rows = append(report.Rows, item)
+			continue
+		}
+
+		book := row.Book
+		if err := h.resolveReferences(ctx.Request.Context(), &book); err != nil {
+			if err != errUnknownGenre && err != errUnknownWork && err != errUnknownAuthor {
+				helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+				return
+			}
+			item.Status, item.Error = entity.ImportRejected, err.Error()
+			report.Rows = append(report.Rows, item)
+			continue
+		}
+		normalizeBookISBN(&book)
+		book.CreatedAt = time.Now().UTC()
+		item.ISBN = book.ISBN
+
+		// the same ISBN appearing twice in the file is a duplicate as well
+		if book.ISBN != "" {
+			if seen[book.ISBN] {
+				item.Status = entity.ImportDuplicate
+				report.Rows = append(report.Rows, item)
+				continue
+			}
+			seen[book.ISBN] = true
+		}
+
+		report.Rows = append(report.Rows, item)
+		batch = append(batch, len(report.Rows)-1)
+		books = append(books, book)
+
+		if len(books) == importBatchSize {
+			if err := flush(); err != nil {
+				helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+				return
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case entity.ImportAccepted:
+			report.Accepted++
+		case entity.ImportRejected:
+			report.Rejected++
+		case entity.ImportDuplicate:
+			report.Duplicates++
+		}
+	}
+	report.Total = len(report.Rows)
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessImportBook, report)
+}
+
+// importFormat returns the requested format or detects it from the uploaded file name
+func importFormat(format string, fileHeader *multipart.FileHeader) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		return services.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return services.ImportFormatNDJSON
+	}
+	return ""
+}
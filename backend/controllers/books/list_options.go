@@ -0,0 +1,152 @@
+package books
+
+import (
+	"errors"
+	"library-books/helpers"
+	"library-books/repository"
+	"library-books/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var errInvalidListQuery = errors.New("invalid list query")
+
+// parseListOptions reads pagination, sorting and filtering from the query string:
+// page, limit, after, sort (e.g. "year,-title"), collapseEditions, author, authorId, workId, genre, isbn,
+// yearFrom, yearTo, createdAfter and updatedBefore (RFC 3339 or YYYY-MM-DD)
+func parseListOptions(ctx *gin.Context) (repository.ListOptions, error) {
+	opts := repository.ListOptions{}
+
+	filter, err := parseBookFilter(ctx)
+	if err != nil {
+		return opts, err
+	}
+	opts.Filter = filter
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		return opts, err
+	}
+	opts.Page, opts.Limit = page, limit
+
+	if after := ctx.Query("after"); after != "" {
+		objectId, err := primitive.ObjectIDFromHex(after)
+		if err != nil {
+			return opts, errInvalidListQuery
+		}
+		opts.After = &objectId
+	}
+
+	sort, err := parseSort(ctx.Query("sort"))
+	if err != nil {
+		return opts, err
+	}
+	opts.Sort = sort
+
+	// a collapsed list is paged by number, the works have no single book to continue after
+	if collapse := ctx.Query("collapseEditions"); collapse != "" {
+		value, err := strconv.ParseBool(collapse)
+		if err != nil || (value && opts.After != nil) {
+			return opts, errInvalidListQuery
+		}
+		opts.CollapseEditions = value
+	}
+
+	return opts, nil
+}
+
+// parseBookFilter reads the book filters from the query string
+func parseBookFilter(ctx *gin.Context) (repository.BookFilter, error) {
+	filter := repository.BookFilter{
+		Author: strings.TrimSpace(ctx.Query("author")),
+		ISBN:   strings.TrimSpace(ctx.Query("isbn")),
+	}
+	if genre := utils.GenreSlug(ctx.Query("genre")); genre != "" {
+		filter.Genres = []string{genre}
+	}
+
+	// books are stored with a normalized ISBN-13, so match any valid ISBN form
+	if isbn, ok := utils.NormalizeISBN(filter.ISBN); ok {
+		filter.ISBN = isbn
+	}
+
+	if authorId := ctx.Query("authorId"); authorId != "" {
+		value, err := primitive.ObjectIDFromHex(authorId)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.AuthorID = value
+	}
+
+	if workId := ctx.Query("workId"); workId != "" {
+		value, err := primitive.ObjectIDFromHex(workId)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.WorkID = value
+	}
+
+	if yearFrom := ctx.Query("yearFrom"); yearFrom != "" {
+		value, err := strconv.Atoi(yearFrom)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.YearFrom = value
+	}
+
+	if yearTo := ctx.Query("yearTo"); yearTo != "" {
+		value, err := strconv.Atoi(yearTo)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.YearTo = value
+	}
+
+	if createdAfter := ctx.Query("createdAfter"); createdAfter != "" {
+		value, err := parseTimeQuery(createdAfter)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.CreatedAfter = value
+	}
+
+	if updatedBefore := ctx.Query("updatedBefore"); updatedBefore != "" {
+		value, err := parseTimeQuery(updatedBefore)
+		if err != nil {
+			return filter, errInvalidListQuery
+		}
+		filter.UpdatedBefore = value
+	}
+
+	return filter, nil
+}
+
+// parseTimeQuery accepts an RFC 3339 timestamp or a plain date, which is read as midnight UTC
+func parseTimeQuery(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseSort parses a comma separated list of fields, a leading "-" sorts descending
+func parseSort(value string) ([]repository.SortField, error) {
+	fields := []repository.SortField{}
+	if value == "" {
+		return fields, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		field := repository.SortField{Field: strings.TrimPrefix(item, "-"), Descending: strings.HasPrefix(item, "-")}
+		if !repository.BookSortFields[field.Field] {
+			return nil, errInvalidListQuery
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
@@ -0,0 +1,234 @@
+package books
+
+import (
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetReviewsHandler godoc
+// @Summary Get the reviews of a book
+// @Description Get a page of the reviews of a book, most recently written first. The average rating and the rating histogram are on the book.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Reviews per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.Reviews,meta=helpers.Pagination} "Reviews retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/reviews [get]
+func (h *BooksController) GetReviewsHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.Reviews.ListByBook(ctx.Request.Context(), bookId, page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetReview, reviews.Reviews, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: reviews.Total,
+	})
+}
+
+// AddReviewHandler godoc
+// @Summary Review a book
+// @Description Rate a book from 1 to 5 with an optional review text, each user reviews a book once. The rating summary of the book is updated with the review.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param review body entity.Review true "Rating and review text"
+// @Success 201 {object} helpers.Response{data=entity.Reviews} "Review added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "The user already reviewed the book"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/reviews [post]
+func (h *BooksController) AddReviewHandler(ctx *gin.Context) {
+	bookId, ok := h.copyBook(ctx)
+	if !ok {
+		return
+	}
+
+	review, ok := h.bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := h.Reviews.Create(ctx.Request.Context(), entity.Reviews{
+		BookID:    bookId,
+		UserID:    middleware.UserID(ctx),
+		Rating:    review.Rating,
+		Text:      review.Text,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateReview {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictReview)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	created, err := h.Reviews.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddReview, created)
+}
+
+// UpdateReviewHandler godoc
+// @Summary Update a review
+// @Description Replace the rating and text of a review written by the authenticated user, the rating summary of the book follows
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param reviewId path string true "Review ID"
+// @Param review body entity.Review true "Rating and review text"
+// @Success 200 {object} helpers.Response{data=entity.Reviews} "Review updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Review written by another user"
+// @Failure 404 {object} helpers.Response "Review not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/reviews/{reviewId} [put]
+func (h *BooksController) UpdateReviewHandler(ctx *gin.Context) {
+	stored, ok := h.ownReview(ctx)
+	if !ok {
+		return
+	}
+
+	review, ok := h.bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.Reviews.Update(ctx.Request.Context(), stored.ID, review)
+	if err != nil {
+		if err == repository.ErrReviewNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundReview)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	updated, err := h.Reviews.Get(ctx.Request.Context(), stored.ID)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateReview, updated)
+}
+
+// DeleteReviewHandler godoc
+// @Summary Delete a review
+// @Description Delete a review written by the authenticated user, its rating leaves the rating summary of the book
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Book ID"
+// @Param reviewId path string true "Review ID"
+// @Success 200 {object} helpers.Response "Review deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Review written by another user"
+// @Failure 404 {object} helpers.Response "Review not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/reviews/{reviewId} [delete]
+func (h *BooksController) DeleteReviewHandler(ctx *gin.Context) {
+	review, ok := h.ownReview(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.Reviews.Delete(ctx.Request.Context(), review.ID)
+	if err != nil {
+		if err == repository.ErrReviewNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundReview)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteReview, nil)
+}
+
+// bindReview reads and validates the review of the request body, the error response is already written when ok is false
+func (h *BooksController) bindReview(ctx *gin.Context) (review entity.Review, ok bool) {
+	if err := ctx.ShouldBindJSON(&review); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return review, false
+	}
+	review.Text = strings.TrimSpace(review.Text)
+
+	// Validate input
+	if err := h.Validate.Struct(review); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return review, false
+	}
+	return review, true
+}
+
+// ownReview loads the review of the reviewId path parameter when it is a review of the book of the id
+// path parameter written by the user, the error response is already written when ok is false
+func (h *BooksController) ownReview(ctx *gin.Context) (review entity.Reviews, ok bool) {
+	bookId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return review, false
+	}
+	reviewId, err := primitive.ObjectIDFromHex(ctx.Param("reviewId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return review, false
+	}
+
+	review, err = h.Reviews.Get(ctx.Request.Context(), reviewId)
+	if err == nil && review.BookID != bookId {
+		err = repository.ErrReviewNotFound
+	}
+	if err != nil {
+		if err == repository.ErrReviewNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundReview)
+			return review, false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return review, false
+	}
+	if review.UserID != middleware.UserID(ctx) {
+		helpers.Forbidden(ctx, http.StatusForbidden, constant.ForbiddenReview)
+		return review, false
+	}
+	return review, true
+}
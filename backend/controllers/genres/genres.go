@@ -0,0 +1,287 @@
+package genres
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"library-books/services"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type GenresController struct {
+	Validate   *validator.Validate
+	Repository repository.GenreRepository
+	Books      repository.BookRepository
+}
+
+// GetGenresHandler godoc
+// @Summary Get the genre taxonomy
+// @Description Get every genre ordered by slug with its parent, its labels and the label in the language of the request (lang query or Accept-Language header)
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param lang query string false "Language of the labels"
+// @Success 200 {object} helpers.Response{data=[]entity.Genres} "Genres retrieved successfully"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /genres [get]
+func (h *GenresController) GetGenresHandler(ctx *gin.Context) {
+	genres, err := h.Repository.List(ctx.Request.Context())
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	services.LocalizeGenres(genres, requestLanguages(ctx)...)
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetGenre, genres)
+}
+
+// GetGenreHandler godoc
+// @Summary Get a genre
+// @Description Get a single genre by slug with the label in the language of the request
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param slug path string true "Genre slug"
+// @Param lang query string false "Language of the label"
+// @Success 200 {object} helpers.Response{data=entity.Genres} "Genre retrieved successfully"
+// @Failure 404 {object} helpers.Response "Genre not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /genres/{slug} [get]
+func (h *GenresController) GetGenreHandler(ctx *gin.Context) {
+	genre, err := h.Repository.Get(ctx.Request.Context(), ctx.Param("slug"))
+	if err != nil {
+		if err == repository.ErrGenreNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundGenre)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	genres := []entity.Genres{genre}
+	services.LocalizeGenres(genres, requestLanguages(ctx)...)
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetGenre, genres[0])
+}
+
+// AddGenreHandler godoc
+// @Summary Add a genre
+// @Description Add a genre to the taxonomy under an optional parent genre. Labels are keyed by language tag and need an English label. Needs the admin role.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param genre body entity.Genre true "Genre data"
+// @Success 201 {object} helpers.Response{data=entity.Genres} "Genre added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input or unknown parent genre"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 409 {object} helpers.Response "A genre with this slug already exists"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /genres [post]
+func (h *GenresController) AddGenreHandler(ctx *gin.Context) {
+	var genre entity.Genre
+	if err := ctx.ShouldBindJSON(&genre); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	genre.Slug = strings.TrimSpace(genre.Slug)
+	if !h.validGenre(ctx, genre) {
+		return
+	}
+
+	genres, err := h.Repository.List(ctx.Request.Context())
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if genre.Parent != "" && !hasGenre(genres, genre.Parent) {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+		return
+	}
+
+	err = h.Repository.Create(ctx.Request.Context(), genre)
+	if err != nil {
+		if err == repository.ErrDuplicateGenre {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictGenre)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	h.writeGenre(ctx, http.StatusCreated, constant.SuccessAddGenre, genre.Slug)
+}
+
+// UpdateGenreHandler godoc
+// @Summary Update a genre
+// @Description Replace the parent and labels of a genre, the slug cannot change. A genre cannot be moved below itself or one of its descendants. Needs the admin role.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Genre slug"
+// @Param genre body entity.Genre true "Genre data"
+// @Success 200 {object} helpers.Response{data=entity.Genres} "Genre updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input, unknown parent genre or parent below the genre"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Genre not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /genres/{slug} [put]
+func (h *GenresController) UpdateGenreHandler(ctx *gin.Context) {
+	var genre entity.Genre
+	if err := ctx.ShouldBindJSON(&genre); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	genre.Slug = ctx.Param("slug")
+	if !h.validGenre(ctx, genre) {
+		return
+	}
+
+	genres, err := h.Repository.List(ctx.Request.Context())
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if !hasGenre(genres, genre.Slug) {
+		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundGenre)
+		return
+	}
+	if genre.Parent != "" {
+		if !hasGenre(genres, genre.Parent) {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownGenre)
+			return
+		}
+		if slices.Contains(services.GenreDescendants(genres, genre.Slug), genre.Parent) {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorGenreCycle)
+			return
+		}
+	}
+
+	err = h.Repository.Update(ctx.Request.Context(), genre.Slug, genre)
+	if err != nil {
+		if err == repository.ErrGenreNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundGenre)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	h.writeGenre(ctx, http.StatusOK, constant.SuccessUpdateGenre, genre.Slug)
+}
+
+// DeleteGenreHandler godoc
+// @Summary Delete a genre
+// @Description Remove a genre from the taxonomy, genres with subgenres or books, including books in the trash, cannot be deleted. Needs the admin role.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Genre slug"
+// @Success 200 {object} helpers.Response "Genre deleted successfully"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Genre not found"
+// @Failure 409 {object} helpers.Response "The genre has subgenres or books"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /genres/{slug} [delete]
+func (h *GenresController) DeleteGenreHandler(ctx *gin.Context) {
+	slug := ctx.Param("slug")
+	genres, err := h.Repository.List(ctx.Request.Context())
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if !hasGenre(genres, slug) {
+		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundGenre)
+		return
+	}
+
+	inUse := len(services.GenreDescendants(genres, slug)) > 1
+	if !inUse {
+		inUse, err = h.hasBooks(ctx.Request.Context(), slug)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+	}
+	if inUse {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictGenreInUse)
+		return
+	}
+
+	err = h.Repository.Delete(ctx.Request.Context(), slug)
+	if err != nil {
+		if err == repository.ErrGenreNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundGenre)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteGenre, nil)
+}
+
+// validGenre validates a genre of the request body, the error response is already written when it returns false
+func (h *GenresController) validGenre(ctx *gin.Context, genre entity.Genre) bool {
+	// Validate input
+	if err := h.Validate.Struct(genre); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return false
+	}
+
+	// English is the fallback language of the labels
+	if genre.Labels["en"] == "" {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return false
+	}
+	return true
+}
+
+// writeGenre responds with the stored genre, localized for the request
+func (h *GenresController) writeGenre(ctx *gin.Context, code int, message string, slug string) {
+	genre, err := h.Repository.Get(ctx.Request.Context(), slug)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	genres := []entity.Genres{genre}
+	services.LocalizeGenres(genres, requestLanguages(ctx)...)
+	helpers.Success(ctx, code, message, genres[0])
+}
+
+// hasBooks reports whether a book, deleted or not, has the genre
+func (h *GenresController) hasBooks(ctx context.Context, slug string) (bool, error) {
+	for _, trashed := range []bool{false, true} {
+		books, err := h.Books.List(ctx, repository.ListOptions{
+			Filter: repository.BookFilter{Genres: []string{slug}, Trashed: trashed},
+			Page:   1,
+			Limit:  1,
+		})
+		if err != nil {
+			return false, err
+		}
+		if books.Total > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasGenre reports whether the taxonomy contains the slug
+func hasGenre(genres []entity.Genres, slug string) bool {
+	return slices.ContainsFunc(genres, func(genre entity.Genres) bool { return genre.Slug == slug })
+}
+
+// requestLanguages returns the languages asked for by the request, the lang query parameter comes first
+func requestLanguages(ctx *gin.Context) []string {
+	return []string{ctx.Query("lang"), ctx.GetHeader("Accept-Language")}
+}
@@ -0,0 +1,268 @@
+package loans
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetProfileFinesHandler godoc
+// @Summary Get the fine balance of the authenticated user
+// @Description Get the fines charged on returned loans, the fines still accruing on overdue loans, the librarian adjustments and the resulting balance
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helpers.Response{data=entity.FineAccount} "Fines retrieved successfully"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/profile/fines [get]
+func (h *LoansController) GetProfileFinesHandler(ctx *gin.Context) {
+	h.writeFineAccount(ctx, http.StatusOK, constant.SuccessGetFine, middleware.UserID(ctx))
+}
+
+// GetProfileFineEntriesHandler godoc
+// @Summary Get the fine adjustments of the authenticated user
+// @Description Get a page of the waivers and adjustments made to the fine balance of the authenticated user, newest first
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.FineEntries} "Fine entries retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/profile/fines/entries [get]
+func (h *LoansController) GetProfileFineEntriesHandler(ctx *gin.Context) {
+	h.writeFineEntries(ctx, middleware.UserID(ctx))
+}
+
+// GetUserFinesHandler godoc
+// @Summary Get the fine balance of a user
+// @Description Librarians get the fines charged, accruing and adjusted of any user and the resulting balance
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Success 200 {object} helpers.Response{data=entity.FineAccount} "Fines retrieved successfully"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /fines/{userId} [get]
+func (h *LoansController) GetUserFinesHandler(ctx *gin.Context) {
+	h.writeFineAccount(ctx, http.StatusOK, constant.SuccessGetFine, ctx.Param("userId"))
+}
+
+// GetUserFineEntriesHandler godoc
+// @Summary Get the fine adjustments of a user
+// @Description Librarians get a page of the waivers and adjustments made to the fine balance of any user, newest first
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.FineEntries} "Fine entries retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /fines/{userId}/entries [get]
+func (h *LoansController) GetUserFineEntriesHandler(ctx *gin.Context) {
+	h.writeFineEntries(ctx, ctx.Param("userId"))
+}
+
+// WaiveFineHandler godoc
+// @Summary Waive fines of a user
+// @Description Librarians forgive part or all of the fine balance of a user, the amount must be positive and at most the balance
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param fine body entity.FineRequest true "Amount to waive and reason"
+// @Success 200 {object} helpers.Response{data=entity.FineAccount} "Fine waived successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Loan not found"
+// @Failure 409 {object} helpers.Response "Amount above the fine balance"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /fines/{userId}/waive [post]
+func (h *LoansController) WaiveFineHandler(ctx *gin.Context) {
+	h.addFineEntry(ctx, entity.FineWaive)
+}
+
+// AdjustFineHandler godoc
+// @Summary Adjust the fine balance of a user
+// @Description Librarians add a positive or negative amount to the fine balance of a user, for example a payment at the desk
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param fine body entity.FineRequest true "Signed amount and reason"
+// @Success 200 {object} helpers.Response{data=entity.FineAccount} "Fine adjusted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Loan not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /fines/{userId}/adjust [post]
+func (h *LoansController) AdjustFineHandler(ctx *gin.Context) {
+	h.addFineEntry(ctx, entity.FineAdjust)
+}
+
+// addFineEntry records a waiver or an adjustment of the user of the route and answers with the new balance
+func (h *LoansController) addFineEntry(ctx *gin.Context, entryType string) {
+	var request entity.FineRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	request.Reason = strings.TrimSpace(request.Reason)
+
+	// Validate input
+	if err := h.Validate.Struct(request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	userID := ctx.Param("userId")
+	entry := entity.FineEntries{
+		UserID:    userID,
+		Type:      entryType,
+		Amount:    services.RoundCents(request.Amount),
+		Reason:    request.Reason,
+		CreatedBy: middleware.UserID(ctx),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	// the entry may point at the loan it settles
+	if request.LoanID != "" {
+		loanId, err := primitive.ObjectIDFromHex(request.LoanID)
+		if err != nil {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return
+		}
+		loan, err := h.Loans.Get(ctx.Request.Context(), loanId)
+		if err == repository.ErrLoanNotFound || (err == nil && loan.UserID != userID) {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundLoan)
+			return
+		}
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+		entry.LoanID = &loanId
+	}
+
+	message := constant.SuccessAdjustFine
+	if entryType == entity.FineWaive {
+		if entry.Amount <= 0 {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return
+		}
+		account, err := h.fineAccount(ctx.Request.Context(), userID, entry.CreatedAt)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+		if entry.Amount > account.Balance {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictFineWaive)
+			return
+		}
+		entry.Amount = -entry.Amount
+		message = constant.SuccessWaiveFine
+	}
+
+	if _, err := h.Fines.Add(ctx.Request.Context(), entry); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	h.writeFineAccount(ctx, http.StatusOK, message, userID)
+}
+
+// writeFineAccount answers with the fine account of a user
+func (h *LoansController) writeFineAccount(ctx *gin.Context, code int, message string, userID string) {
+	account, err := h.fineAccount(ctx.Request.Context(), userID, time.Now().UTC())
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, code, message, account)
+}
+
+// writeFineEntries answers with a page of the fine ledger of a user
+func (h *LoansController) writeFineEntries(ctx *gin.Context, userID string) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	entries, err := h.Fines.List(ctx.Request.Context(), userID, page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetFine, entries.Entries, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: entries.Total,
+	})
+}
+
+// fineAccount adds up the fines of a user at the given time: the fines charged on returned loans,
+// the fines accruing on loans still out past their due date and the ledger entries
+func (h *LoansController) fineAccount(ctx context.Context, userID string, now time.Time) (entity.FineAccount, error) {
+	account := entity.FineAccount{UserID: userID, Loans: []entity.LoanFine{}}
+
+	loans, err := h.Loans.ListLate(ctx, userID, now)
+	if err != nil {
+		return account, err
+	}
+	for _, loan := range loans {
+		days, amount := h.Policy.Fines.Fine(loan, now)
+		accruing := loan.Status == entity.LoanActive
+		if accruing {
+			account.Accruing += amount
+		} else {
+			// a returned loan keeps the fine charged at its return, whatever the policy says today
+			amount = loan.Fine
+			account.Charged += amount
+		}
+		account.Loans = append(account.Loans, entity.LoanFine{
+			LoanID:      loan.ID,
+			BookID:      loan.BookID,
+			Material:    loan.Material,
+			DueAt:       loan.DueAt,
+			ReturnedAt:  loan.ReturnedAt,
+			DaysOverdue: days,
+			Amount:      amount,
+			Accruing:    accruing,
+		})
+	}
+
+	adjusted, err := h.Fines.Sum(ctx, userID)
+	if err != nil {
+		return account, err
+	}
+
+	account.Charged = services.RoundCents(account.Charged)
+	account.Accruing = services.RoundCents(account.Accruing)
+	account.Adjusted = services.RoundCents(adjusted)
+	account.Balance = services.RoundCents(account.Charged + account.Accruing + account.Adjusted)
+	return account, nil
+}
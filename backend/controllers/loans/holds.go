@@ -0,0 +1,253 @@
+package loans
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlaceHoldHandler godoc
+// @Summary Place a hold on a book
+// @Description Join the queue for a book that has no copy on the shelf. Holds are served first in first out, a returned copy is set aside for the next hold until the pickup deadline of the circulation policy.
+// @Tags Holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param hold body entity.HoldRequest true "Book to hold"
+// @Success 201 {object} helpers.Response{data=entity.Holds} "Hold placed successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 409 {object} helpers.Response "A copy is available or the user already holds the book"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /holds [post]
+func (h *LoansController) PlaceHoldHandler(ctx *gin.Context) {
+	var request entity.HoldRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	// Validate input
+	if err := h.Validate.Struct(request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	bookId, err := primitive.ObjectIDFromHex(request.BookID)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Books.Get(ctx.Request.Context(), bookId); err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// a copy on the shelf can be checked out right away
+	availability, err := h.Copies.Availability(ctx.Request.Context(), []primitive.ObjectID{bookId})
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if availability[bookId].Available > 0 {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictHoldCopyAvailable)
+		return
+	}
+
+	id, err := h.Holds.Place(ctx.Request.Context(), entity.Holds{
+		UserID:   middleware.UserID(ctx),
+		BookID:   bookId,
+		PlacedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateHold {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictHold)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	hold, err := h.Holds.Get(ctx.Request.Context(), id)
+	if err == nil {
+		err = h.setPositions(ctx.Request.Context(), []entity.Holds{hold})
+	}
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessPlaceHold, hold)
+}
+
+// GetHoldHandler godoc
+// @Summary Get a hold
+// @Description Get a hold of the authenticated user, a waiting hold has its position in the queue
+// @Tags Holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hold ID"
+// @Success 200 {object} helpers.Response{data=entity.Holds} "Hold retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Hold belongs to another user"
+// @Failure 404 {object} helpers.Response "Hold not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /holds/{id} [get]
+func (h *LoansController) GetHoldHandler(ctx *gin.Context) {
+	hold, ok := h.ownHold(ctx)
+	if !ok {
+		return
+	}
+
+	holds := []entity.Holds{hold}
+	if err := h.setPositions(ctx.Request.Context(), holds); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetHold, holds[0])
+}
+
+// CancelHoldHandler godoc
+// @Summary Cancel a hold
+// @Description Leave the queue of a book, the copy set aside for a ready hold passes to the next hold
+// @Tags Holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hold ID"
+// @Success 200 {object} helpers.Response{data=entity.Holds} "Hold cancelled successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Hold belongs to another user"
+// @Failure 404 {object} helpers.Response "Hold not found"
+// @Failure 409 {object} helpers.Response "Hold already closed"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /holds/{id} [delete]
+func (h *LoansController) CancelHoldHandler(ctx *gin.Context) {
+	hold, ok := h.ownHold(ctx)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	err := h.Holds.Cancel(ctx.Request.Context(), hold.ID, now, now.Add(h.Policy.PickupWindow))
+	if err != nil {
+		if err == repository.ErrHoldNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundHold)
+			return
+		}
+		if err == repository.ErrHoldNotActive {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictHoldNotActive)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	cancelled, err := h.Holds.Get(ctx.Request.Context(), hold.ID)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessCancelHold, cancelled)
+}
+
+// GetProfileHoldsHandler godoc
+// @Summary Get the holds of the authenticated user
+// @Description Get a page of the holds of the authenticated user, most recently placed first. Waiting holds have their position in the queue.
+// @Tags Holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by hold status (waiting, ready, fulfilled, expired or cancelled)"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.Holds} "Holds retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/profile/holds [get]
+func (h *LoansController) GetProfileHoldsHandler(ctx *gin.Context) {
+	status := ctx.Query("status")
+	switch status {
+	case "", entity.HoldWaiting, entity.HoldReady, entity.HoldFulfilled, entity.HoldExpired, entity.HoldCancelled:
+	default:
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	holds, err := h.Holds.ListByUser(ctx.Request.Context(), middleware.UserID(ctx), status, page, limit)
+	if err == nil {
+		err = h.setPositions(ctx.Request.Context(), holds.Holds)
+	}
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetHold, holds.Holds, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: holds.Total,
+	})
+}
+
+// ownHold loads the hold of the route and checks it belongs to the authenticated user,
+// the error response is already written when ok is false
+func (h *LoansController) ownHold(ctx *gin.Context) (hold entity.Holds, ok bool) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return hold, false
+	}
+
+	hold, err = h.Holds.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrHoldNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundHold)
+			return hold, false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return hold, false
+	}
+	if hold.UserID != middleware.UserID(ctx) {
+		helpers.Forbidden(ctx, http.StatusForbidden, constant.ForbiddenHold)
+		return hold, false
+	}
+	return hold, true
+}
+
+// setPositions fills the queue position of the waiting holds
+func (h *LoansController) setPositions(ctx context.Context, holds []entity.Holds) error {
+	for i := range holds {
+		if holds[i].Status != entity.HoldWaiting {
+			continue
+		}
+		position, err := h.Holds.Position(ctx, holds[i])
+		if err != nil {
+			return err
+		}
+		holds[i].Position = position
+	}
+	return nil
+}
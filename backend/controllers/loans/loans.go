@@ -0,0 +1,332 @@
+package loans
+
+import (
+	"errors"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var errInvalidCopyID = errors.New("invalid copy id")
+
+// Policy holds the circulation rules, read from the loans, holds and fines sections of config.json.
+// Checkout is blocked while the fine balance of the borrower is above FineLimit, zero disables the block.
+type Policy struct {
+	Period       time.Duration
+	MaxRenewals  int
+	MaxActive    int
+	PickupWindow time.Duration
+	Fines        services.FinePolicy
+	FineLimit    float64
+}
+
+type LoansController struct {
+	Validate *validator.Validate
+	Books    repository.BookRepository
+	Copies   repository.CopyRepository
+	Loans    repository.LoanRepository
+	Holds    repository.HoldRepository
+	Fines    repository.FineRepository
+	Policy   Policy
+}
+
+// CheckoutHandler godoc
+// @Summary Check out a copy
+// @Description Lend an available copy, or the copy set aside for the user's hold, found by its ID or barcode, to the authenticated user. The due date follows the loan period of the circulation policy and the user's open hold on the book is fulfilled.
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param loan body entity.LoanRequest true "Copy to check out"
+// @Success 201 {object} helpers.Response{data=entity.Loans} "Copy checked out successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Copy or book not found"
+// @Failure 409 {object} helpers.Response "Copy not available, loan limit reached or fine balance above the limit"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /loans [post]
+func (h *LoansController) CheckoutHandler(ctx *gin.Context) {
+	var request entity.LoanRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	// Validate input
+	if err := h.Validate.Struct(request); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	copy, err := h.findCopy(ctx, request)
+	if err != nil {
+		if err == repository.ErrCopyNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCopy)
+			return
+		}
+		if err == errInvalidCopyID {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	// copies of a book in the trash cannot be lent
+	if _, err := h.Books.Get(ctx.Request.Context(), copy.BookID); err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	userID := middleware.UserID(ctx)
+	active, err := h.Loans.CountActive(ctx.Request.Context(), userID)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if h.Policy.MaxActive > 0 && active >= int64(h.Policy.MaxActive) {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictLoanLimit)
+		return
+	}
+
+	now := time.Now().UTC()
+	if h.Policy.FineLimit > 0 {
+		account, err := h.fineAccount(ctx.Request.Context(), userID, now)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+		if account.Balance > h.Policy.FineLimit {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictFineLimit)
+			return
+		}
+	}
+
+	id, err := h.Loans.Checkout(ctx.Request.Context(), entity.Loans{
+		UserID:       userID,
+		BookID:       copy.BookID,
+		CopyID:       copy.ID,
+		Material:     copy.Material,
+		CheckedOutAt: now,
+		DueAt:        now.Add(h.Policy.Period),
+	}, now.Add(h.Policy.PickupWindow))
+	if err != nil {
+		if err == repository.ErrCopyNotAvailable {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictCopyNotAvailable)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	loan, err := h.Loans.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessCheckoutLoan, loan)
+}
+
+// ReturnHandler godoc
+// @Summary Return a loan
+// @Description Librarians close an active loan, charging the late fee of the fine policy when it is overdue, and set its copy aside for the next hold on the book, or put it back on the shelf when nobody is waiting
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Loan ID"
+// @Success 200 {object} helpers.Response{data=entity.Loans} "Loan returned successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Librarian role required"
+// @Failure 404 {object} helpers.Response "Loan not found"
+// @Failure 409 {object} helpers.Response "Loan already returned"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /loans/{id}/return [post]
+func (h *LoansController) ReturnHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	loan, err := h.Loans.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrLoanNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundLoan)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	now := time.Now().UTC()
+	_, fine := h.Policy.Fines.Fine(loan, now)
+	err = h.Loans.Return(ctx.Request.Context(), objectId, now, now.Add(h.Policy.PickupWindow), fine)
+	if err != nil {
+		if err == repository.ErrLoanNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundLoan)
+			return
+		}
+		if err == repository.ErrLoanNotActive {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictLoanNotActive)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	returned, err := h.Loans.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessReturnLoan, returned)
+}
+
+// RenewHandler godoc
+// @Summary Renew a loan
+// @Description Extend the due date of an active loan of the authenticated user by the loan period (counted from now when overdue), up to the renewal limit of the circulation policy. Loans of books other patrons hold cannot be renewed.
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Loan ID"
+// @Success 200 {object} helpers.Response{data=entity.Loans} "Loan renewed successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Loan belongs to another user"
+// @Failure 404 {object} helpers.Response "Loan not found"
+// @Failure 409 {object} helpers.Response "Loan already returned, renewal limit reached or book on hold"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /loans/{id}/renew [post]
+func (h *LoansController) RenewHandler(ctx *gin.Context) {
+	objectId, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	loan, err := h.Loans.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		if err == repository.ErrLoanNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundLoan)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if loan.UserID != middleware.UserID(ctx) {
+		helpers.Forbidden(ctx, http.StatusForbidden, constant.ForbiddenLoan)
+		return
+	}
+
+	// the copy is due back for the patrons waiting on the book
+	waiting, err := h.Holds.CountWaiting(ctx.Request.Context(), loan.BookID)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if loan.Status == entity.LoanActive && waiting > 0 {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictRenewalHolds)
+		return
+	}
+
+	// extend from the current due date, or from now when the loan is already overdue
+	dueAt := loan.DueAt
+	if now := time.Now().UTC(); now.After(dueAt) {
+		dueAt = now
+	}
+
+	err = h.Loans.Renew(ctx.Request.Context(), objectId, dueAt.Add(h.Policy.Period), h.Policy.MaxRenewals)
+	if err != nil {
+		if err == repository.ErrLoanNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundLoan)
+			return
+		}
+		if err == repository.ErrLoanNotActive {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictLoanNotActive)
+			return
+		}
+		if err == repository.ErrRenewalLimit {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictRenewalLimit)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	renewed, err := h.Loans.Get(ctx.Request.Context(), objectId)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessRenewLoan, renewed)
+}
+
+// GetProfileLoansHandler godoc
+// @Summary Get the loans of the authenticated user
+// @Description Get a page of the loans of the authenticated user, most recent checkout first
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by loan status (active or returned)"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, between 1 and 100 (default 20)"
+// @Success 200 {object} helpers.Response{data=[]entity.Loans} "Loans retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/profile/loans [get]
+func (h *LoansController) GetProfileLoansHandler(ctx *gin.Context) {
+	status := ctx.Query("status")
+	if status != "" && status != entity.LoanActive && status != entity.LoanReturned {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	loans, err := h.Loans.ListByUser(ctx.Request.Context(), middleware.UserID(ctx), status, page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetLoan, loans.Loans, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: loans.Total,
+	})
+}
+
+// findCopy looks the requested copy up by ID, or by barcode when no ID is given
+func (h *LoansController) findCopy(ctx *gin.Context, request entity.LoanRequest) (entity.Copies, error) {
+	if request.CopyID == "" {
+		return h.Copies.FindByBarcode(ctx.Request.Context(), strings.TrimSpace(request.Barcode))
+	}
+
+	copyId, err := primitive.ObjectIDFromHex(request.CopyID)
+	if err != nil {
+		return entity.Copies{}, errInvalidCopyID
+	}
+	return h.Copies.Find(ctx.Request.Context(), copyId)
+}
@@ -0,0 +1,248 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"library-books/constant"
+	"library-books/helpers"
+	"library-books/repository"
+	"library-books/services"
+	"library-books/storage"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/singleflight"
+)
+
+type MediaController struct {
+	// Books give the current cover upload of a book for the URLs without an upload ID
+	Books  repository.BookRepository
+	Covers storage.BlobStore
+	// Cache keeps the resized covers, it can be cleared at any time
+	Cache storage.BlobStore
+	// Sizes are the boxes a cover can be resized to, other sizes are refused
+	Sizes []services.CoverSize
+	// Resizes bounds the covers resized at once, its capacity is the limit and nil leaves them unbounded
+	Resizes chan struct{}
+
+	// resizing collapses the concurrent cache misses of a variant into one resize
+	resizing singleflight.Group
+}
+
+// GetCoverHandler serves a cover upload of a book as stored, or one of its JPEG thumbnails named by the
+// size query parameter. Every upload has its own URL whose content never changes, the URL without an
+// upload ID serves the current cover of the book. With w, h, fit (contain or cover) and format (jpeg or
+// webp) the cover is resized to one of the allowed sizes, the result is cached on disk until the cover is
+// replaced. Responses carry a strong ETag derived from the stored cover and the requested variant. Media
+// routes live outside the /api/v1 base path, so they are not in the Swagger docs.
+func (h *MediaController) GetCoverHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	upload, ok := h.upload(ctx, id)
+	if !ok {
+		return
+	}
+
+	size := ctx.Query("size")
+	resize := resizeQuery(ctx)
+	if size != "" && (resize || !thumbnailSize(size)) {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	variant, ok := h.bindVariant(ctx)
+	if resize && !ok {
+		return
+	}
+
+	content, info, ok := h.cover(ctx, services.CoverKey(id.Hex(), upload, size))
+	if !ok {
+		return
+	}
+	defer content.Close()
+
+	if !resize {
+		serveCover(ctx, content, info.Size, info.ContentType, info.ModifiedAt, coverETag(info.Key, sourceStamp(info)))
+		return
+	}
+
+	key := variant.Key(id.Hex(), upload, sourceStamp(info))
+	etag := coverETag(key)
+	cached, cachedInfo, err := h.Cache.Get(ctx.Request.Context(), key)
+	if err == nil {
+		defer cached.Close()
+		serveCover(ctx, cached, cachedInfo.Size, variant.ContentType(), info.ModifiedAt, etag)
+		return
+	}
+	if err != storage.ErrBlobNotFound {
+		log.Println("read cached cover:", err)
+	}
+
+	// concurrent requests of the same missing variant share one resize, which outlives the request that started it
+	encoded, err, _ := h.resizing.Do(key, func() (interface{}, error) {
+		return h.resizeCover(context.WithoutCancel(ctx.Request.Context()), content, variant, key)
+	})
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	data := encoded.([]byte)
+	serveCover(ctx, bytes.NewReader(data), int64(len(data)), variant.ContentType(), info.ModifiedAt, etag)
+}
+
+// upload returns the cover upload named in the URL, or the current one of the book when the URL has none.
+// The error response is already written when ok is false.
+func (h *MediaController) upload(ctx *gin.Context, id primitive.ObjectID) (upload string, ok bool) {
+	if upload = ctx.Param("upload"); upload != "" {
+		uploadId, err := primitive.ObjectIDFromHex(upload)
+		if err != nil {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return "", false
+		}
+		return uploadId.Hex(), true
+	}
+
+	book, err := h.Books.Get(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCover)
+			return "", false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return "", false
+	}
+	// covers uploaded before per-upload keys, and books without a served cover, have no upload ID
+	upload, _ = services.CoverUploadID(id.Hex(), book.CoverImageUrl)
+	return upload, true
+}
+
+// resizeCover renders a variant of a stored cover and caches it under key, waiting for a free slot of Resizes
+func (h *MediaController) resizeCover(ctx context.Context, content io.Reader, variant services.CoverVariant, key string) ([]byte, error) {
+	if h.Resizes != nil {
+		h.Resizes <- struct{}{}
+		defer func() { <-h.Resizes }()
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := services.DecodeCover(data)
+	if err != nil {
+		return nil, err
+	}
+	var encoded bytes.Buffer
+	if err := services.EncodeCover(&encoded, services.FitCover(img, variant), variant.Format); err != nil {
+		return nil, err
+	}
+
+	// a failed cache write only costs the next request another resize
+	if err := h.Cache.Put(ctx, key, bytes.NewReader(encoded.Bytes()), variant.ContentType()); err != nil {
+		log.Println("cache resized cover:", err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// bindVariant reads the resize query parameters, only the allowed sizes are accepted.
+// The error response is already written when ok is false.
+func (h *MediaController) bindVariant(ctx *gin.Context) (variant services.CoverVariant, ok bool) {
+	if !resizeQuery(ctx) {
+		return variant, false
+	}
+
+	variant = services.CoverVariant{Fit: services.CoverFitContain, Format: services.CoverFormatJPEG}
+	var err error
+	if value := ctx.Query("w"); value != "" {
+		variant.Width, err = strconv.Atoi(value)
+	}
+	if value := ctx.Query("h"); value != "" && err == nil {
+		variant.Height, err = strconv.Atoi(value)
+	}
+	if value := ctx.Query("fit"); value != "" {
+		variant.Fit = value
+	}
+	if value := ctx.Query("format"); value != "" {
+		variant.Format = value
+	}
+	if err != nil ||
+		(variant.Fit != services.CoverFitContain && variant.Fit != services.CoverFitCover) ||
+		(variant.Format != services.CoverFormatJPEG && variant.Format != services.CoverFormatWebP) {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return variant, false
+	}
+
+	for _, size := range h.Sizes {
+		if size == variant.CoverSize {
+			return variant, true
+		}
+	}
+	helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorCoverSize)
+	return variant, false
+}
+
+// resizeQuery reports whether any of the resize query parameters is given
+func resizeQuery(ctx *gin.Context) bool {
+	for _, name := range []string{"w", "h", "fit", "format"} {
+		if _, present := ctx.GetQuery(name); present {
+			return true
+		}
+	}
+	return false
+}
+
+// cover opens a stored cover image, the error response is already written when ok is false
+func (h *MediaController) cover(ctx *gin.Context, key string) (content io.ReadCloser, info storage.BlobInfo, ok bool) {
+	content, info, err := h.Covers.Get(ctx.Request.Context(), key)
+	if err != nil {
+		if err == storage.ErrBlobNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundCover)
+			return nil, info, false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return nil, info, false
+	}
+	return content, info, true
+}
+
+// serveCover writes an image with its validators, the conditional requests are answered by the HTTP cache middleware
+func serveCover(ctx *gin.Context, content io.Reader, size int64, contentType string, modified time.Time, etag string) {
+	ctx.DataFromReader(http.StatusOK, size, contentType, content, map[string]string{
+		"ETag":                   etag,
+		"Last-Modified":          modified.UTC().Format(http.TimeFormat),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// sourceStamp identifies the stored version of a cover, a new upload changes its modification time and usually its size
+func sourceStamp(info storage.BlobInfo) string {
+	return fmt.Sprintf("%x-%x", info.ModifiedAt.UnixNano(), info.Size)
+}
+
+// coverETag is a strong entity tag over the given parts, the same stored cover and variant always give the same bytes
+func coverETag(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%s;", part)
+	}
+	return `"` + base64.RawURLEncoding.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+func thumbnailSize(name string) bool {
+	for _, thumbnail := range services.CoverThumbnails {
+		if thumbnail.Name == name {
+			return true
+		}
+	}
+	return false
+}
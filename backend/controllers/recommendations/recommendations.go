@@ -0,0 +1,158 @@
+package recommendations
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecommendationsController struct {
+	Recommendations repository.RecommendationRepository
+	Books           repository.BookRepository
+}
+
+// GetSimilarBooksHandler godoc
+// @Summary Get the books similar to a book
+// @Description Get a page of the books most similar to a book, best match first. Books score for a shared author and genre and for being shelved or borrowed by the same readers. The scores are refreshed by a background job, so a new book has no similar books until the next refresh. Books deleted since the refresh are left out of the page.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Books per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.RecommendedBook,meta=helpers.Pagination} "Similar books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Book not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /books/{id}/similar [get]
+func (h *RecommendationsController) GetSimilarBooksHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+	if _, err := h.Books.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	similar, err := h.Recommendations.Similar(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	books, err := h.books(ctx.Request.Context(), pageOf(similar.Books, page, limit))
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetSimilarBook, books, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: int64(len(similar.Books)),
+	})
+}
+
+// GetUserRecommendationsHandler godoc
+// @Summary Get the books recommended to the authenticated user
+// @Description Get a page of the books most similar to the books the authenticated user shelved or borrowed, leaving out the ones they already have. The recommendations are refreshed by a background job, until it scored the user the best rated books are recommended with a score of 0.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Books per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.RecommendedBook,meta=helpers.Pagination} "Recommendations retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/recommendations [get]
+func (h *RecommendationsController) GetUserRecommendationsHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	recommendations, err := h.Recommendations.ForUser(ctx.Request.Context(), middleware.UserID(ctx))
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	if len(recommendations.Books) == 0 {
+		bestRated, err := h.Books.List(ctx.Request.Context(), repository.ListOptions{
+			Sort:  []repository.SortField{{Field: "rating", Descending: true}},
+			Page:  page,
+			Limit: limit,
+		})
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+
+		books := make([]entity.RecommendedBook, len(bestRated.Books))
+		for i, book := range bestRated.Books {
+			books[i] = entity.RecommendedBook{Books: book}
+		}
+		helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetRecommendation, books, helpers.Pagination{
+			Page:  page,
+			Limit: limit,
+			Total: bestRated.Total,
+		})
+		return
+	}
+
+	books, err := h.books(ctx.Request.Context(), pageOf(recommendations.Books, page, limit))
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetRecommendation, books, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: int64(len(recommendations.Books)),
+	})
+}
+
+// books loads the scored books, the books deleted since they were scored are left out
+func (h *RecommendationsController) books(ctx context.Context, scored []entity.ScoredBook) ([]entity.RecommendedBook, error) {
+	books := []entity.RecommendedBook{}
+	for _, candidate := range scored {
+		book, err := h.Books.Get(ctx, candidate.BookID)
+		if err == repository.ErrBookNotFound {
+			continue
+		}
+		if err != nil {
+			return books, err
+		}
+		books = append(books, entity.RecommendedBook{Books: book, Score: candidate.Score})
+	}
+	return books, nil
+}
+
+func pageOf(scored []entity.ScoredBook, page, limit int64) []entity.ScoredBook {
+	start := (page - 1) * limit
+	if start >= int64(len(scored)) {
+		return nil
+	}
+	return scored[start:min(start+limit, int64(len(scored)))]
+}
@@ -0,0 +1,323 @@
+package shelves
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetShelfBooksHandler godoc
+// @Summary Get the books on a shelf
+// @Description Get a page of the books on a shelf of the authenticated user or on a public shelf, most recently added first. Books deleted since they were added have no book.
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Books per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.ShelfItems,meta=helpers.Pagination} "Books retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Shelf not found or private"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id}/books [get]
+func (h *ShelvesController) GetShelfBooksHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	shelf, ok := h.readableShelf(ctx)
+	if !ok {
+		return
+	}
+
+	items, err := h.Shelves.ListItems(ctx.Request.Context(), shelf.ID, page, limit)
+	if err == nil {
+		err = h.setBooks(ctx.Request.Context(), items.Items)
+	}
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetShelfBook, items.Items, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: items.Total,
+	})
+}
+
+// AddShelfBookHandler godoc
+// @Summary Add a book to a shelf
+// @Description Put a book on a shelf of the authenticated user with optional reading dates and notes. A book is on at most one reading status shelf: adding it to one takes it off the others and keeps its start date and notes. The reading shelf starts and the read shelf finishes the book now unless dates are given.
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param item body entity.ShelfItem true "Book and reading details"
+// @Success 201 {object} helpers.Response{data=entity.ShelfItems} "Book added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Shelf belongs to another user"
+// @Failure 404 {object} helpers.Response "Shelf or book not found"
+// @Failure 409 {object} helpers.Response "The book is already on the shelf"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id}/books [post]
+func (h *ShelvesController) AddShelfBookHandler(ctx *gin.Context) {
+	shelf, ok := h.ownShelf(ctx)
+	if !ok {
+		return
+	}
+
+	request, ok := h.bindItem(ctx)
+	if !ok {
+		return
+	}
+	bookId, err := primitive.ObjectIDFromHex(request.BookID)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	book, err := h.Books.Get(ctx.Request.Context(), bookId)
+	if err != nil {
+		if err == repository.ErrBookNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	now := time.Now().UTC()
+	item := entity.ShelfItems{
+		ShelfID:    shelf.ID,
+		BookID:     bookId,
+		AddedAt:    now,
+		StartedAt:  request.StartedAt,
+		FinishedAt: request.FinishedAt,
+		Notes:      request.Notes,
+	}
+
+	// the reading status shelves form one status per book, the previous status is replaced
+	var moved []primitive.ObjectID
+	if shelf.Kind != entity.ShelfCustom {
+		moved, err = h.carryOver(ctx.Request.Context(), shelf, &item)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+		if shelf.Kind == entity.ShelfReading && item.StartedAt == nil {
+			item.StartedAt = &now
+		}
+		if shelf.Kind == entity.ShelfRead && item.FinishedAt == nil {
+			item.FinishedAt = &now
+		}
+	}
+	if !validDates(item.StartedAt, item.FinishedAt) {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	item.ID, err = h.Shelves.AddItem(ctx.Request.Context(), item)
+	if err != nil {
+		if err == repository.ErrDuplicateShelfItem {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictShelfBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	for _, shelfID := range moved {
+		err := h.Shelves.RemoveItem(ctx.Request.Context(), shelfID, bookId)
+		if err != nil && err != repository.ErrShelfItemNotFound {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+	}
+
+	item.Book = &book
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddShelfBook, item)
+}
+
+// UpdateShelfBookHandler godoc
+// @Summary Update a book on a shelf
+// @Description Replace the reading dates and notes of a book on a shelf of the authenticated user
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param bookId path string true "Book ID"
+// @Param item body entity.ShelfItem true "Reading details"
+// @Success 200 {object} helpers.Response{data=entity.ShelfItems} "Book updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Shelf belongs to another user"
+// @Failure 404 {object} helpers.Response "Shelf not found or the book is not on it"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id}/books/{bookId} [put]
+func (h *ShelvesController) UpdateShelfBookHandler(ctx *gin.Context) {
+	shelf, ok := h.ownShelf(ctx)
+	if !ok {
+		return
+	}
+
+	bookId, err := primitive.ObjectIDFromHex(ctx.Param("bookId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	request, ok := h.bindItem(ctx)
+	if !ok {
+		return
+	}
+	if !validDates(request.StartedAt, request.FinishedAt) {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	err = h.Shelves.UpdateItem(ctx.Request.Context(), shelf.ID, bookId, entity.ShelfItems{
+		StartedAt:  request.StartedAt,
+		FinishedAt: request.FinishedAt,
+		Notes:      request.Notes,
+	})
+	if err != nil {
+		if err == repository.ErrShelfItemNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelfBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	item, err := h.Shelves.GetItem(ctx.Request.Context(), shelf.ID, bookId)
+	items := []entity.ShelfItems{item}
+	if err == nil {
+		err = h.setBooks(ctx.Request.Context(), items)
+	}
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateShelfBook, items[0])
+}
+
+// RemoveShelfBookHandler godoc
+// @Summary Remove a book from a shelf
+// @Description Take a book off a shelf of the authenticated user
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param bookId path string true "Book ID"
+// @Success 200 {object} helpers.Response "Book removed successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Shelf belongs to another user"
+// @Failure 404 {object} helpers.Response "Shelf not found or the book is not on it"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id}/books/{bookId} [delete]
+func (h *ShelvesController) RemoveShelfBookHandler(ctx *gin.Context) {
+	shelf, ok := h.ownShelf(ctx)
+	if !ok {
+		return
+	}
+
+	bookId, err := primitive.ObjectIDFromHex(ctx.Param("bookId"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	err = h.Shelves.RemoveItem(ctx.Request.Context(), shelf.ID, bookId)
+	if err != nil {
+		if err == repository.ErrShelfItemNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelfBook)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessRemoveShelfBook, nil)
+}
+
+// bindItem reads and validates the shelf item of the request body, the error response is already written when ok is false
+func (h *ShelvesController) bindItem(ctx *gin.Context) (item entity.ShelfItem, ok bool) {
+	if err := ctx.ShouldBindJSON(&item); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return item, false
+	}
+	item.Notes = strings.TrimSpace(item.Notes)
+
+	// Validate input
+	if err := h.Validate.Struct(item); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return item, false
+	}
+	return item, true
+}
+
+// carryOver finds the book on the other reading status shelves of the owner of shelf and copies its start date
+// and notes to item when item has none. It returns the shelves the book has to leave.
+func (h *ShelvesController) carryOver(ctx context.Context, shelf entity.Shelves, item *entity.ShelfItems) ([]primitive.ObjectID, error) {
+	shelves, err := h.Shelves.ListByUser(ctx, shelf.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []primitive.ObjectID
+	for _, other := range shelves {
+		if other.Kind == entity.ShelfCustom || other.ID == shelf.ID {
+			continue
+		}
+		previous, err := h.Shelves.GetItem(ctx, other.ID, item.BookID)
+		if err == repository.ErrShelfItemNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		moved = append(moved, other.ID)
+		if item.StartedAt == nil {
+			item.StartedAt = previous.StartedAt
+		}
+		if item.Notes == "" {
+			item.Notes = previous.Notes
+		}
+	}
+	return moved, nil
+}
+
+// setBooks fills the book of each item, items whose book was deleted keep no book
+func (h *ShelvesController) setBooks(ctx context.Context, items []entity.ShelfItems) error {
+	for i := range items {
+		book, err := h.Books.Get(ctx, items[i].BookID)
+		if err == repository.ErrBookNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		items[i].Book = &book
+	}
+	return nil
+}
+
+// validDates reports whether a book was not finished before it was started
+func validDates(startedAt, finishedAt *time.Time) bool {
+	return startedAt == nil || finishedAt == nil || !finishedAt.Before(*startedAt)
+}
@@ -0,0 +1,273 @@
+package shelves
+
+import (
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/middleware"
+	"library-books/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ShelvesController struct {
+	Validate *validator.Validate
+	Shelves  repository.ShelfRepository
+	Books    repository.BookRepository
+}
+
+// GetShelvesHandler godoc
+// @Summary Get the shelves of a user
+// @Description Get the shelves of the authenticated user with their book count, the reading status shelves (want_to_read, reading and read) first. With userId, get the public shelves of that user instead.
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId query string false "Owner of the public shelves to list"
+// @Success 200 {object} helpers.Response{data=[]entity.Shelves} "Shelves retrieved successfully"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves [get]
+func (h *ShelvesController) GetShelvesHandler(ctx *gin.Context) {
+	userID := middleware.UserID(ctx)
+	owner := ctx.Query("userId")
+	if owner != "" && owner != userID {
+		shelves, err := h.Shelves.ListByUser(ctx.Request.Context(), owner, true)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return
+		}
+		helpers.Success(ctx, http.StatusOK, constant.SuccessGetShelf, shelves)
+		return
+	}
+
+	if err := h.Shelves.EnsureDefaults(ctx.Request.Context(), userID); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	shelves, err := h.Shelves.ListByUser(ctx.Request.Context(), userID, false)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetShelf, shelves)
+}
+
+// AddShelfHandler godoc
+// @Summary Add a shelf
+// @Description Add a custom shelf for the authenticated user, names are unique per user ignoring case
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param shelf body entity.Shelf true "Shelf data"
+// @Success 201 {object} helpers.Response{data=entity.Shelves} "Shelf added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 409 {object} helpers.Response "A shelf with this name already exists"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves [post]
+func (h *ShelvesController) AddShelfHandler(ctx *gin.Context) {
+	shelf, ok := h.bindShelf(ctx)
+	if !ok {
+		return
+	}
+
+	// the reading status shelves keep their names
+	userID := middleware.UserID(ctx)
+	if err := h.Shelves.EnsureDefaults(ctx.Request.Context(), userID); err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	id, err := h.Shelves.Create(ctx.Request.Context(), userID, shelf)
+	if err != nil {
+		if err == repository.ErrDuplicateShelf {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictShelf)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	created, err := h.Shelves.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddShelf, created)
+}
+
+// GetShelfHandler godoc
+// @Summary Get a shelf
+// @Description Get a shelf of the authenticated user or a public shelf of another user
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Success 200 {object} helpers.Response{data=entity.Shelves} "Shelf retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Shelf not found or private"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id} [get]
+func (h *ShelvesController) GetShelfHandler(ctx *gin.Context) {
+	shelf, ok := h.readableShelf(ctx)
+	if !ok {
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetShelf, shelf)
+}
+
+// UpdateShelfHandler godoc
+// @Summary Update a shelf
+// @Description Rename a shelf of the authenticated user or change whether it is public. The reading status shelves cannot be renamed.
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param shelf body entity.Shelf true "Shelf data"
+// @Success 200 {object} helpers.Response{data=entity.Shelves} "Shelf updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Shelf belongs to another user"
+// @Failure 404 {object} helpers.Response "Shelf not found"
+// @Failure 409 {object} helpers.Response "A shelf with this name already exists or the shelf is a reading status shelf"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id} [put]
+func (h *ShelvesController) UpdateShelfHandler(ctx *gin.Context) {
+	stored, ok := h.ownShelf(ctx)
+	if !ok {
+		return
+	}
+
+	shelf, ok := h.bindShelf(ctx)
+	if !ok {
+		return
+	}
+	if stored.Kind != entity.ShelfCustom && shelf.Name != stored.Name {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictDefaultShelf)
+		return
+	}
+
+	err := h.Shelves.Update(ctx.Request.Context(), stored.ID, shelf)
+	if err != nil {
+		if err == repository.ErrShelfNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelf)
+			return
+		}
+		if err == repository.ErrDuplicateShelf {
+			helpers.Conflict(ctx, http.StatusConflict, constant.ConflictShelf)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	updated, err := h.Shelves.Get(ctx.Request.Context(), stored.ID)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateShelf, updated)
+}
+
+// DeleteShelfHandler godoc
+// @Summary Delete a shelf
+// @Description Delete a custom shelf of the authenticated user with the books on it
+// @Tags Shelves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Success 200 {object} helpers.Response "Shelf deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Shelf belongs to another user"
+// @Failure 404 {object} helpers.Response "Shelf not found"
+// @Failure 409 {object} helpers.Response "The shelf is a reading status shelf"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /users/shelves/{id} [delete]
+func (h *ShelvesController) DeleteShelfHandler(ctx *gin.Context) {
+	shelf, ok := h.ownShelf(ctx)
+	if !ok {
+		return
+	}
+	if shelf.Kind != entity.ShelfCustom {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictDefaultShelf)
+		return
+	}
+
+	err := h.Shelves.Delete(ctx.Request.Context(), shelf.ID)
+	if err != nil {
+		if err == repository.ErrShelfNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelf)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteShelf, nil)
+}
+
+// bindShelf reads and validates the shelf of the request body, the error response is already written when ok is false
+func (h *ShelvesController) bindShelf(ctx *gin.Context) (shelf entity.Shelf, ok bool) {
+	if err := ctx.ShouldBindJSON(&shelf); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return shelf, false
+	}
+	shelf.Name = strings.TrimSpace(shelf.Name)
+
+	// Validate input
+	if err := h.Validate.Struct(shelf); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return shelf, false
+	}
+	return shelf, true
+}
+
+// readableShelf loads the shelf of the id path parameter when the user owns it or it is public.
+// The private shelves of other users are reported as not found. The error response is already written when ok is false.
+func (h *ShelvesController) readableShelf(ctx *gin.Context) (shelf entity.Shelves, ok bool) {
+	shelf, ok = h.shelf(ctx)
+	if ok && !shelf.Public && shelf.UserID != middleware.UserID(ctx) {
+		helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelf)
+		return shelf, false
+	}
+	return shelf, ok
+}
+
+// ownShelf loads the shelf of the id path parameter when the user owns it, the error response is already written when ok is false
+func (h *ShelvesController) ownShelf(ctx *gin.Context) (shelf entity.Shelves, ok bool) {
+	shelf, ok = h.readableShelf(ctx)
+	if ok && shelf.UserID != middleware.UserID(ctx) {
+		helpers.Forbidden(ctx, http.StatusForbidden, constant.ForbiddenShelf)
+		return shelf, false
+	}
+	return shelf, ok
+}
+
+func (h *ShelvesController) shelf(ctx *gin.Context) (shelf entity.Shelves, ok bool) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return shelf, false
+	}
+
+	shelf, err = h.Shelves.Get(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrShelfNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundShelf)
+			return shelf, false
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return shelf, false
+	}
+	return shelf, true
+}
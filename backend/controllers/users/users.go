@@ -64,6 +64,9 @@ func (h *UsersController) RegisterHandler(ctx *gin.Context) {
 	hashedPassword := HashPassword(user.Password)
 	user.Password = hashedPassword
 
+	// staff roles are only granted in the database
+	user.Role = ""
+
 	// Generate UUID for ID
 	user.ID = GenerateUUID()
 
@@ -116,7 +119,8 @@ func (h *UsersController) LoginHandler(ctx *gin.Context) {
 
 	// adding expire token 30 minutes
 	claimCustom := entity.JWTClaims{
-		ID: foundUser.ID,
+		ID:   foundUser.ID,
+		Role: foundUser.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)), // Expires in 30 minutes
 		},
@@ -169,7 +173,7 @@ func (h *UsersController) ProfileHandler(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"id": user.ID, "msisdn": user.MSISDN, "name": user.Name, "username": user.Username})
+	ctx.JSON(http.StatusOK, gin.H{"id": user.ID, "msisdn": user.MSISDN, "name": user.Name, "username": user.Username, "role": user.Role})
 }
 
 // function to generate UUID
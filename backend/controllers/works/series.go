@@ -0,0 +1,262 @@
+package works
+
+import (
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetSeriesListHandler godoc
+// @Summary Get all series
+// @Description Get a page of series ordered by title, q matches part of the title ignoring case
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Param q query string false "Part of the title"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Series per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.SeriesRecord,meta=helpers.Pagination} "Series retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series [get]
+func (h *WorksController) GetSeriesListHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	series, err := h.Series.List(ctx.Request.Context(), strings.TrimSpace(ctx.Query("q")), page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetSeries, series.Series, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: series.Total,
+	})
+}
+
+// AddSeriesHandler godoc
+// @Summary Add a series
+// @Description Add a numbered series, works join it with their position through the work endpoints. Needs the admin role.
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param series body entity.Series true "Series data"
+// @Success 201 {object} helpers.Response{data=entity.SeriesRecord} "Series added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series [post]
+func (h *WorksController) AddSeriesHandler(ctx *gin.Context) {
+	series, ok := h.bindSeries(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := h.Series.Create(ctx.Request.Context(), series)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	created, err := h.Series.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddSeries, created)
+}
+
+// GetSeriesHandler godoc
+// @Summary Get a series
+// @Description Get a single series by ID
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} helpers.Response{data=entity.SeriesRecord} "Series retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Series not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series/{id} [get]
+func (h *WorksController) GetSeriesHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	series, err := h.Series.Get(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrSeriesNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundSeries)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetSeries, series)
+}
+
+// UpdateSeriesHandler godoc
+// @Summary Update a series
+// @Description Replace the title and description of a series. Needs the admin role.
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Series ID"
+// @Param series body entity.Series true "Series data"
+// @Success 200 {object} helpers.Response{data=entity.SeriesRecord} "Series updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Series not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series/{id} [put]
+func (h *WorksController) UpdateSeriesHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	series, ok := h.bindSeries(ctx)
+	if !ok {
+		return
+	}
+
+	err = h.Series.Update(ctx.Request.Context(), id, series)
+	if err != nil {
+		if err == repository.ErrSeriesNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundSeries)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	updated, err := h.Series.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateSeries, updated)
+}
+
+// DeleteSeriesHandler godoc
+// @Summary Delete a series
+// @Description Delete a series, series that still have works cannot be deleted. Needs the admin role.
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Series ID"
+// @Success 200 {object} helpers.Response "Series deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Series not found"
+// @Failure 409 {object} helpers.Response "Works belong to the series"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series/{id} [delete]
+func (h *WorksController) DeleteSeriesHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	works, err := h.Works.ListBySeries(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if len(works) > 0 {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictSeriesHasWorks)
+		return
+	}
+
+	err = h.Series.Delete(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrSeriesNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundSeries)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteSeries, nil)
+}
+
+// GetSeriesWorksHandler godoc
+// @Summary Get the works of a series
+// @Description Get every work of a series in reading order with its position
+// @Tags Series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} helpers.Response{data=[]entity.SeriesWork} "Works retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Series not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /series/{id}/works [get]
+func (h *WorksController) GetSeriesWorksHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Series.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrSeriesNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundSeries)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	works, err := h.Works.ListBySeries(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	ordered := make([]entity.SeriesWork, len(works))
+	for i, work := range works {
+		position, _ := work.Position(id)
+		ordered[i] = entity.SeriesWork{Position: position, Works: work}
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetWork, ordered)
+}
+
+// bindSeries reads and validates the series of the request body, the error response is already written when ok is false
+func (h *WorksController) bindSeries(ctx *gin.Context) (series entity.Series, ok bool) {
+	if err := ctx.ShouldBindJSON(&series); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return series, false
+	}
+	series.Title = strings.TrimSpace(series.Title)
+
+	// Validate input
+	if err := h.Validate.Struct(series); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return series, false
+	}
+	return series, true
+}
@@ -0,0 +1,353 @@
+package works
+
+import (
+	"context"
+	"library-books/constant"
+	"library-books/entity"
+	"library-books/helpers"
+	"library-books/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WorksController struct {
+	Validate *validator.Validate
+	Works    repository.WorkRepository
+	Series   repository.SeriesRepository
+	Books    repository.BookRepository
+	Authors  repository.AuthorRepository
+}
+
+// GetWorksHandler godoc
+// @Summary Get all works
+// @Description Get a page of works ordered by title, q matches part of the title ignoring case
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Param q query string false "Part of the title"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Works per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.Works,meta=helpers.Pagination} "Works retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works [get]
+func (h *WorksController) GetWorksHandler(ctx *gin.Context) {
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	works, err := h.Works.List(ctx.Request.Context(), strings.TrimSpace(ctx.Query("q")), page, limit)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetWork, works.Works, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: works.Total,
+	})
+}
+
+// AddWorkHandler godoc
+// @Summary Add a work
+// @Description Add a work that groups the editions and translations of a book, with its authors and its position in series. Needs the admin role.
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param work body entity.Work true "Work data"
+// @Success 201 {object} helpers.Response{data=entity.Works} "Work added successfully"
+// @Failure 400 {object} helpers.Response "Invalid input, unknown author or unknown series"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works [post]
+func (h *WorksController) AddWorkHandler(ctx *gin.Context) {
+	work, ok := h.bindWork(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := h.Works.Create(ctx.Request.Context(), work)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	created, err := h.Works.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusCreated, constant.SuccessAddWork, created)
+}
+
+// GetWorkHandler godoc
+// @Summary Get a work
+// @Description Get a single work by ID
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Success 200 {object} helpers.Response{data=entity.Works} "Work retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Work not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works/{id} [get]
+func (h *WorksController) GetWorkHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	work, err := h.Works.Get(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrWorkNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessGetWork, work)
+}
+
+// UpdateWorkHandler godoc
+// @Summary Update a work
+// @Description Replace the title, authors, original language, first publication year, description and series of a work. Needs the admin role.
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Work ID"
+// @Param work body entity.Work true "Work data"
+// @Success 200 {object} helpers.Response{data=entity.Works} "Work updated successfully"
+// @Failure 400 {object} helpers.Response "Invalid input, unknown author or unknown series"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Work not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works/{id} [put]
+func (h *WorksController) UpdateWorkHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	work, ok := h.bindWork(ctx)
+	if !ok {
+		return
+	}
+
+	err = h.Works.Update(ctx.Request.Context(), id, work)
+	if err != nil {
+		if err == repository.ErrWorkNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	updated, err := h.Works.Get(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessUpdateWork, updated)
+}
+
+// DeleteWorkHandler godoc
+// @Summary Delete a work
+// @Description Delete a work, works that still have editions, including editions in the trash, cannot be deleted. Needs the admin role.
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Work ID"
+// @Success 200 {object} helpers.Response "Work deleted successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 403 {object} helpers.Response "Insufficient role"
+// @Failure 404 {object} helpers.Response "Work not found"
+// @Failure 409 {object} helpers.Response "Books are editions of the work"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works/{id} [delete]
+func (h *WorksController) DeleteWorkHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Works.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrWorkNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	referenced, err := h.hasEditions(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+	if referenced {
+		helpers.Conflict(ctx, http.StatusConflict, constant.ConflictWorkHasEditions)
+		return
+	}
+
+	err = h.Works.Delete(ctx.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrWorkNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.Success(ctx, http.StatusOK, constant.SuccessDeleteWork, nil)
+}
+
+// GetWorkEditionsHandler godoc
+// @Summary Get the editions of a work
+// @Description Get a page of the books that are editions or translations of a work, oldest first
+// @Tags Works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param limit query int false "Books per page, at most 100"
+// @Success 200 {object} helpers.Response{data=[]entity.Books,meta=helpers.Pagination} "Editions retrieved successfully"
+// @Failure 400 {object} helpers.Response "Invalid input"
+// @Failure 404 {object} helpers.Response "Work not found"
+// @Failure 500 {object} helpers.Response "Database error"
+// @Router /works/{id}/editions [get]
+func (h *WorksController) GetWorkEditionsHandler(ctx *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	page, limit, err := helpers.ParsePagination(ctx)
+	if err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return
+	}
+
+	if _, err := h.Works.Get(ctx.Request.Context(), id); err != nil {
+		if err == repository.ErrWorkNotFound {
+			helpers.NotFound(ctx, http.StatusNotFound, constant.NotfoundWork)
+			return
+		}
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	books, err := h.Books.List(ctx.Request.Context(), repository.ListOptions{
+		Filter: repository.BookFilter{WorkID: id},
+		Sort:   []repository.SortField{{Field: "year"}},
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+		return
+	}
+
+	helpers.SuccessWithMeta(ctx, http.StatusOK, constant.SuccessGetBook, books.Books, helpers.Pagination{
+		Page:  page,
+		Limit: limit,
+		Total: books.Total,
+	})
+}
+
+// bindWork reads and validates the work of the request body and checks its authors and series exist,
+// the error response is already written when ok is false
+func (h *WorksController) bindWork(ctx *gin.Context) (work entity.Work, ok bool) {
+	if err := ctx.ShouldBindJSON(&work); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return work, false
+	}
+	work.Title = strings.TrimSpace(work.Title)
+	if work.AuthorIDs == nil {
+		work.AuthorIDs = []primitive.ObjectID{}
+	}
+	if work.Series == nil {
+		work.Series = []entity.SeriesEntry{}
+	}
+
+	// Validate input
+	if err := h.Validate.Struct(work); err != nil {
+		helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+		return work, false
+	}
+
+	// a work has a single position in each series
+	seriesIDs := []primitive.ObjectID{}
+	seen := map[primitive.ObjectID]bool{}
+	for _, entry := range work.Series {
+		if entry.SeriesID.IsZero() || seen[entry.SeriesID] {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorInvalidInput)
+			return work, false
+		}
+		seen[entry.SeriesID] = true
+		seriesIDs = append(seriesIDs, entry.SeriesID)
+	}
+
+	if len(work.AuthorIDs) > 0 {
+		exists, err := h.Authors.Exists(ctx.Request.Context(), work.AuthorIDs)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return work, false
+		}
+		if !exists {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownAuthor)
+			return work, false
+		}
+	}
+
+	if len(seriesIDs) > 0 {
+		exists, err := h.Series.Exists(ctx.Request.Context(), seriesIDs)
+		if err != nil {
+			helpers.ServerError(ctx, http.StatusInternalServerError, constant.ErrorDatabase)
+			return work, false
+		}
+		if !exists {
+			helpers.BadRequest(ctx, http.StatusBadRequest, constant.ErrorUnknownSeries)
+			return work, false
+		}
+	}
+	return work, true
+}
+
+// hasEditions reports whether a book, deleted or not, is an edition of the work
+func (h *WorksController) hasEditions(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	for _, trashed := range []bool{false, true} {
+		books, err := h.Books.List(ctx, repository.ListOptions{
+			Filter: repository.BookFilter{WorkID: id, Trashed: trashed},
+			Page:   1,
+			Limit:  1,
+		})
+		if err != nil {
+			return false, err
+		}
+		if books.Total > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// text index used by the book search, title matches weigh more than author and description. The stemming
+// language of each book is read from textLanguage, written by the book repository from the book language.
+func init() {
+	register(Migration{
+		Version:     1,
+		Description: "create books text index",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "title", Value: "text"},
+					{Key: "author", Value: "text"},
+					{Key: "description", Value: "text"},
+				},
+				Options: options.Index().
+					SetName("books_text").
+					SetWeights(bson.M{"title": 10, "author": 5, "description": 1}).
+					SetDefaultLanguage("english").
+					SetLanguageOverride("textLanguage"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().DropOne(ctx, "books_text")
+			return err
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"library-books/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// backfill books saved before ISBN normalization, invalid ISBNs are left untouched
+func init() {
+	register(Migration{
+		Version:     2,
+		Description: "normalize book ISBNs to ISBN-13",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			collection := database.Collection("books")
+			cursor, err := collection.Find(ctx, bson.M{"isbn": bson.M{"$gt": ""}, "originalIsbn": bson.M{"$exists": false}})
+			if err != nil {
+				return err
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var book struct {
+					ID   interface{} `bson:"_id"`
+					ISBN string      `bson:"isbn"`
+				}
+				if err := cursor.Decode(&book); err != nil {
+					return err
+				}
+
+				isbn, ok := utils.NormalizeISBN(book.ISBN)
+				if !ok {
+					continue
+				}
+				_, err := collection.UpdateOne(ctx, bson.M{"_id": book.ID}, bson.M{"$set": bson.M{"isbn": isbn, "originalIsbn": book.ISBN}})
+				if err != nil {
+					return err
+				}
+			}
+			return cursor.Err()
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").UpdateMany(ctx,
+				bson.M{"originalIsbn": bson.M{"$exists": true}},
+				bson.A{bson.M{"$set": bson.M{"isbn": "$originalIsbn"}}, bson.M{"$unset": "originalIsbn"}},
+			)
+			return err
+		},
+	})
+}
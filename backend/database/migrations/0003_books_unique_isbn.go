@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// one book per ISBN, books without an ISBN are excluded from the index. Normalizing ISBN-10s in migration 2
+// can turn two records of a book into duplicates, those are reported instead of failing the index build.
+func init() {
+	register(Migration{
+		Version:     3,
+		Description: "create books unique isbn index",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			collection := database.Collection("books")
+			if err := checkUnique(ctx, collection, "isbn", bson.M{"isbn": bson.M{"$gt": ""}}); err != nil {
+				return err
+			}
+
+			_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{{Key: "isbn", Value: 1}},
+				Options: options.Index().
+					SetName("books_isbn_unique").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"isbn": bson.M{"$gt": ""}}),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().DropOne(ctx, "books_isbn_unique")
+			return err
+		},
+	})
+}
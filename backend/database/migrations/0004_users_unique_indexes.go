@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// usernames and MSISDNs identify users at registration and login
+func init() {
+	register(Migration{
+		Version:     4,
+		Description: "create users unique username and msisdn indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			collection := database.Collection("users")
+			for _, field := range []string{"username", "msisdn"} {
+				if err := checkUnique(ctx, collection, field, bson.M{}); err != nil {
+					return err
+				}
+			}
+
+			_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "username", Value: 1}},
+					Options: options.Index().SetName("users_username_unique").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "msisdn", Value: 1}},
+					Options: options.Index().SetName("users_msisdn_unique").SetUnique(true),
+				},
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			indexes := database.Collection("users").Indexes()
+			if _, err := indexes.DropOne(ctx, "users_username_unique"); err != nil {
+				return err
+			}
+			_, err := indexes.DropOne(ctx, "users_msisdn_unique")
+			return err
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sparse index on the soft delete marker, used by the trash list and the purge job
+func init() {
+	register(Migration{
+		Version:     5,
+		Description: "create books deletedAt index",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+				Options: options.Index().SetName("books_deleted_at").SetSparse(true),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().DropOne(ctx, "books_deleted_at")
+			return err
+		},
+	})
+}
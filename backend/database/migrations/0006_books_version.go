@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// books saved before optimistic concurrency start at version 1
+func init() {
+	register(Migration{
+		Version:     6,
+		Description: "backfill book versions",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").UpdateMany(ctx,
+				bson.M{"version": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"version": 1}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}})
+			return err
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// legacyTimestampLayout is the layout of the timestamps written with time.Now().String()
+const legacyTimestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// timestamp fields stored as time.Now().String() text, keyed by collection
+var legacyTimestampFields = map[string][]string{
+	"books": {"createdAt", "updatedAt"},
+	"urls":  {"createdAt"},
+}
+
+// convert createdAt/updatedAt text to BSON dates, a creation time that cannot be parsed falls back
+// to the ObjectID timestamp and an empty or unparsable update time is removed
+func init() {
+	register(Migration{
+		Version:     7,
+		Description: "convert string timestamps to dates",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			for name, fields := range legacyTimestampFields {
+				err := convertTimestamps(ctx, database.Collection(name), fields, "string", func(id primitive.ObjectID, field string, value interface{}) interface{} {
+					text, _ := value.(string)
+					if parsed, ok := parseLegacyTimestamp(text); ok {
+						return parsed
+					}
+					if field == "createdAt" {
+						return id.Timestamp().UTC()
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			for name, fields := range legacyTimestampFields {
+				err := convertTimestamps(ctx, database.Collection(name), fields, "date", func(id primitive.ObjectID, field string, value interface{}) interface{} {
+					date, _ := value.(primitive.DateTime)
+					return date.Time().UTC().String()
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// convertTimestamps rewrites every field of the given BSON type with convert, a nil result unsets the field
+func convertTimestamps(ctx context.Context, collection *mongo.Collection, fields []string, bsonType string, convert func(id primitive.ObjectID, field string, value interface{}) interface{}) error {
+	or := bson.A{}
+	for _, field := range fields {
+		or = append(or, bson.M{field: bson.M{"$type": bsonType}})
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"$or": or})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			return err
+		}
+		id, _ := document["_id"].(primitive.ObjectID)
+
+		set, unset := bson.M{}, bson.M{}
+		for _, field := range fields {
+			value, ok := document[field]
+			if !ok || !hasBSONType(value, bsonType) {
+				continue
+			}
+			if converted := convert(id, field, value); converted != nil {
+				set[field] = converted
+			} else {
+				unset[field] = ""
+			}
+		}
+
+		update := bson.M{}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+		if len(update) == 0 {
+			continue
+		}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": document["_id"]}, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func hasBSONType(value interface{}, bsonType string) bool {
+	switch value.(type) {
+	case string:
+		return bsonType == "string"
+	case primitive.DateTime:
+		return bsonType == "date"
+	}
+	return false
+}
+
+// parseLegacyTimestamp parses time.Now().String() output, dropping the monotonic clock reading
+func parseLegacyTimestamp(value string) (time.Time, bool) {
+	value, _, _ = strings.Cut(strings.TrimSpace(value), " m=")
+	if value == "" {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(legacyTimestampLayout, value)
+	if err != nil {
+		// tolerate values that were already written as RFC 3339 text
+		if parsed, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return parsed.UTC(), true
+}
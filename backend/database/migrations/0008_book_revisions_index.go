@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// one revision per book version, also serves the newest first history listing
+func init() {
+	register(Migration{
+		Version:     8,
+		Description: "create book_revisions unique book revision index",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("book_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "bookId", Value: 1}, {Key: "revision", Value: -1}},
+				Options: options.Index().SetName("book_revisions_book_revision").SetUnique(true),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("book_revisions").Indexes().DropOne(ctx, "book_revisions_book_revision")
+			return err
+		},
+	})
+}
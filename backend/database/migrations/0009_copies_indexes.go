@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// barcodes are unique across the library, copies are listed and counted per book
+func init() {
+	register(Migration{
+		Version:     9,
+		Description: "create copies barcode and book indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("copies").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "barcode", Value: 1}},
+					Options: options.Index().SetName("copies_barcode").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "bookId", Value: 1}, {Key: "status", Value: 1}},
+					Options: options.Index().SetName("copies_book_status"),
+				},
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			for _, name := range []string{"copies_barcode", "copies_book_status"} {
+				if _, err := database.Collection("copies").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
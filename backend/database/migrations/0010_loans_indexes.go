@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// a copy is on at most one active loan, patrons list their loans newest first
+func init() {
+	register(Migration{
+		Version:     10,
+		Description: "create loans active copy and user indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("loans").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys: bson.D{{Key: "copyId", Value: 1}},
+					Options: options.Index().SetName("loans_active_copy").SetUnique(true).
+						SetPartialFilterExpression(bson.M{"status": "active"}),
+				},
+				{
+					Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "status", Value: 1}, {Key: "checkedOutAt", Value: -1}},
+					Options: options.Index().SetName("loans_user_status"),
+				},
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			for _, name := range []string{"loans_active_copy", "loans_user_status"} {
+				if _, err := database.Collection("loans").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// waiting holds are served in placement order per book, patrons list their holds newest first
+// and the expiry job looks up ready holds past their pickup deadline
+func init() {
+	register(Migration{
+		Version:     11,
+		Description: "create holds queue, user and pickup indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("holds").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "bookId", Value: 1}, {Key: "status", Value: 1}, {Key: "placedAt", Value: 1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("holds_queue"),
+				},
+				{
+					Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "placedAt", Value: -1}},
+					Options: options.Index().SetName("holds_user"),
+				},
+				{
+					Keys:    bson.D{{Key: "status", Value: 1}, {Key: "pickupBy", Value: 1}},
+					Options: options.Index().SetName("holds_pickup"),
+				},
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			for _, name := range []string{"holds_queue", "holds_user", "holds_pickup"} {
+				if _, err := database.Collection("holds").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// the fine ledger is listed and summed per user, newest entry first
+func init() {
+	register(Migration{
+		Version:     12,
+		Description: "create fines user index",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("fines").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}},
+				Options: options.Index().SetName("fines_user"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("fines").Indexes().DropOne(ctx, "fines_user")
+			return err
+		},
+	})
+}
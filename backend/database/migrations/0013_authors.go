@@ -0,0 +1,136 @@
+package migrations
+
+import (
+	"context"
+	"library-books/utils"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// authors are matched on their normalized names, books are listed per author.
+// Books saved before authors existed are linked to the author of their author text: the spellings
+// that normalize to the same name become one author named after the most used spelling, an author
+// already stored with that name is reused and names matching several authors are left unlinked.
+func init() {
+	register(Migration{
+		Version:     13,
+		Description: "create authors indexes and link books to authors",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("authors").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "nameKeys", Value: 1}},
+					Options: options.Index().SetName("authors_name_keys"),
+				},
+				{
+					Keys:    bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("authors_name"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			books := database.Collection("books")
+			_, err = books.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "authorIds", Value: 1}},
+				Options: options.Index().SetName("books_author_ids"),
+			})
+			if err != nil {
+				return err
+			}
+
+			// spellings of the unlinked books, most used first
+			cursor, err := books.Aggregate(ctx, mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"author": bson.M{"$gt": ""}, "authorIds": bson.M{"$exists": false}}}},
+				{{Key: "$group", Value: bson.M{"_id": "$author", "count": bson.M{"$sum": 1}}}},
+				{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+			})
+			if err != nil {
+				return err
+			}
+			var spellings []struct {
+				Author string `bson:"_id"`
+			}
+			if err := cursor.All(ctx, &spellings); err != nil {
+				return err
+			}
+
+			keys := []string{}
+			forms := map[string][]string{}
+			for _, spelling := range spellings {
+				key := utils.AuthorNameKey(spelling.Author)
+				if key == "" {
+					continue
+				}
+				if _, ok := forms[key]; !ok {
+					keys = append(keys, key)
+				}
+				forms[key] = append(forms[key], spelling.Author)
+			}
+
+			authors := database.Collection("authors")
+			for _, key := range keys {
+				var matches []struct {
+					ID primitive.ObjectID `bson:"_id"`
+				}
+				cursor, err := authors.Find(ctx, bson.M{"nameKeys": key}, options.Find().SetLimit(2).SetProjection(bson.M{"_id": 1}))
+				if err != nil {
+					return err
+				}
+				if err := cursor.All(ctx, &matches); err != nil {
+					return err
+				}
+
+				var id primitive.ObjectID
+				switch len(matches) {
+				case 0:
+					id = primitive.NewObjectID()
+					_, err := authors.InsertOne(ctx, bson.M{
+						"_id":         id,
+						"name":        forms[key][0],
+						"variants":    forms[key][1:],
+						"biography":   "",
+						"identifiers": bson.M{},
+						"nameKeys":    []string{key},
+						"createdAt":   time.Now().UTC(),
+					})
+					if err != nil {
+						return err
+					}
+				case 1:
+					id = matches[0].ID
+				default:
+					continue
+				}
+
+				_, err = books.UpdateMany(ctx,
+					bson.M{"author": bson.M{"$in": forms[key]}, "authorIds": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"authorIds": []primitive.ObjectID{id}}},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			books := database.Collection("books")
+			if _, err := books.UpdateMany(ctx, bson.M{"authorIds": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"authorIds": ""}}); err != nil {
+				return err
+			}
+			if _, err := books.Indexes().DropOne(ctx, "books_author_ids"); err != nil {
+				return err
+			}
+			for _, name := range []string{"authors_name_keys", "authors_name"} {
+				if _, err := database.Collection("authors").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
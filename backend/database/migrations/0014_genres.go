@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"context"
+	"library-books/utils"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// books store the slug of a genre of the taxonomy. The free text genres of existing books become
+// top level genres: the spellings that share a slug are merged under the English label of the most
+// used spelling, and the books are rewritten to the slug.
+func init() {
+	register(Migration{
+		Version:     14,
+		Description: "create genres from book genres and store book genres as slugs",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			books := database.Collection("books")
+			_, err := books.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "genre", Value: 1}},
+				Options: options.Index().SetName("books_genre"),
+			})
+			if err != nil {
+				return err
+			}
+
+			// spellings of the book genres, most used first
+			cursor, err := books.Aggregate(ctx, mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"genre": bson.M{"$gt": ""}}}},
+				{{Key: "$group", Value: bson.M{"_id": "$genre", "count": bson.M{"$sum": 1}}}},
+				{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+			})
+			if err != nil {
+				return err
+			}
+			var spellings []struct {
+				Genre string `bson:"_id"`
+			}
+			if err := cursor.All(ctx, &spellings); err != nil {
+				return err
+			}
+
+			slugs := []string{}
+			forms := map[string][]string{}
+			for _, spelling := range spellings {
+				slug := utils.GenreSlug(spelling.Genre)
+				if slug == "" {
+					continue
+				}
+				if _, ok := forms[slug]; !ok {
+					slugs = append(slugs, slug)
+				}
+				forms[slug] = append(forms[slug], spelling.Genre)
+			}
+
+			genres := database.Collection("genres")
+			for _, slug := range slugs {
+				_, err := genres.UpdateOne(ctx,
+					bson.M{"_id": slug},
+					bson.M{"$setOnInsert": bson.M{"labels": bson.M{"en": forms[slug][0]}, "createdAt": time.Now().UTC()}},
+					options.Update().SetUpsert(true),
+				)
+				if err != nil {
+					return err
+				}
+
+				_, err = books.UpdateMany(ctx, bson.M{"genre": bson.M{"$in": forms[slug]}}, bson.M{"$set": bson.M{"genre": slug}})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			cursor, err := database.Collection("genres").Find(ctx, bson.M{})
+			if err != nil {
+				return err
+			}
+			var genres []struct {
+				Slug   string            `bson:"_id"`
+				Labels map[string]string `bson:"labels"`
+			}
+			if err := cursor.All(ctx, &genres); err != nil {
+				return err
+			}
+
+			// books get the English label of their genre back
+			books := database.Collection("books")
+			for _, genre := range genres {
+				if genre.Labels["en"] == "" {
+					continue
+				}
+				_, err := books.UpdateMany(ctx, bson.M{"genre": genre.Slug}, bson.M{"$set": bson.M{"genre": genre.Labels["en"]}})
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = books.Indexes().DropOne(ctx, "books_genre")
+			return err
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// editions are listed per work, works per series, and works and series are listed by title
+func init() {
+	register(Migration{
+		Version:     15,
+		Description: "create works, series and book work indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "workId", Value: 1}, {Key: "year", Value: 1}},
+				Options: options.Index().SetName("books_work"),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = database.Collection("works").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "series.seriesId", Value: 1}},
+					Options: options.Index().SetName("works_series"),
+				},
+				{
+					Keys:    bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("works_title"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = database.Collection("series").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}},
+				Options: options.Index().SetName("series_title"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			if _, err := database.Collection("books").Indexes().DropOne(ctx, "books_work"); err != nil {
+				return err
+			}
+			for _, name := range []string{"works_series", "works_title"} {
+				if _, err := database.Collection("works").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			_, err := database.Collection("series").Indexes().DropOne(ctx, "series_title")
+			return err
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// shelf names are unique per user ignoring case and a book is on a shelf once, the books of a shelf are listed by date added
+func init() {
+	register(Migration{
+		Version:     16,
+		Description: "create shelves and shelf items indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("shelves").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{{Key: "userId", Value: 1}, {Key: "name", Value: 1}},
+				Options: options.Index().
+					SetName("shelves_user_name").
+					SetUnique(true).
+					SetCollation(&options.Collation{Locale: "en", Strength: 2}),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = database.Collection("shelf_items").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "shelfId", Value: 1}, {Key: "bookId", Value: 1}},
+					Options: options.Index().SetName("shelf_items_shelf_book").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "shelfId", Value: 1}, {Key: "addedAt", Value: -1}, {Key: "_id", Value: -1}},
+					Options: options.Index().SetName("shelf_items_shelf_added"),
+				},
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			if _, err := database.Collection("shelves").Indexes().DropOne(ctx, "shelves_user_name"); err != nil {
+				return err
+			}
+			for _, name := range []string{"shelf_items_shelf_book", "shelf_items_shelf_added"} {
+				if _, err := database.Collection("shelf_items").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// a user reviews a book once, the reviews of a book are listed newest first and the catalog sorts by average rating
+func init() {
+	register(Migration{
+		Version:     17,
+		Description: "create reviews and book rating indexes",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("reviews").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "bookId", Value: 1}, {Key: "userId", Value: 1}},
+					Options: options.Index().SetName("reviews_book_user").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "bookId", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}},
+					Options: options.Index().SetName("reviews_book_created"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = database.Collection("books").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "rating.average", Value: 1}, {Key: "_id", Value: 1}},
+				Options: options.Index().SetName("books_rating"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			for _, name := range []string{"reviews_book_user", "reviews_book_created"} {
+				if _, err := database.Collection("reviews").Indexes().DropOne(ctx, name); err != nil {
+					return err
+				}
+			}
+			_, err := database.Collection("books").Indexes().DropOne(ctx, "books_rating")
+			return err
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// the text index stems Indonesian books without language rules, MongoDB has no Indonesian stemmer
+func init() {
+	register(Migration{
+		Version:     18,
+		Description: "backfill book text index languages",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			books := database.Collection("books")
+			_, err := books.UpdateMany(ctx,
+				bson.M{"textLanguage": bson.M{"$exists": false}, "language": bson.M{"$regex": "^id(-|$)", "$options": "i"}},
+				bson.M{"$set": bson.M{"textLanguage": "none"}},
+			)
+			if err != nil {
+				return err
+			}
+			_, err = books.UpdateMany(ctx,
+				bson.M{"textLanguage": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"textLanguage": "english"}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			_, err := database.Collection("books").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"textLanguage": ""}})
+			return err
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// one live book per ISBN: books in the trash each have their own deletedAt, so they keep their ISBN
+// without blocking a new book, while live books all index deletedAt as null and still conflict
+func init() {
+	register(Migration{
+		Version:     19,
+		Description: "scope the unique isbn index to live books",
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			books := database.Collection("books")
+			_, err := books.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{{Key: "isbn", Value: 1}, {Key: "deletedAt", Value: 1}},
+				Options: options.Index().
+					SetName("books_isbn_live_unique").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"isbn": bson.M{"$gt": ""}}),
+			})
+			if err != nil {
+				return err
+			}
+			_, err = books.Indexes().DropOne(ctx, "books_isbn_unique")
+			return err
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			// books in the trash share ISBNs with live books once the index covers them again
+			books := database.Collection("books")
+			if err := checkUnique(ctx, books, "isbn", bson.M{"isbn": bson.M{"$gt": ""}}); err != nil {
+				return err
+			}
+			_, err := books.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{{Key: "isbn", Value: 1}},
+				Options: options.Index().
+					SetName("books_isbn_unique").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"isbn": bson.M{"$gt": ""}}),
+			})
+			if err != nil {
+				return err
+			}
+			_, err = books.Indexes().DropOne(ctx, "books_isbn_live_unique")
+			return err
+		},
+	})
+}
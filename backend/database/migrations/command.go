@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RunCommand runs the migrate command line: "up" (default), "down [steps]" or "status"
+func RunCommand(ctx context.Context, database *mongo.Database, args []string, out io.Writer) error {
+	migrator := NewMigrator(database)
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d %s\n", migration.Version, migration.Description)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			value, err := strconv.Atoi(args[1])
+			if err != nil || value < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = value
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			fmt.Fprintf(out, "rolled back %d %s\n", migration.Version, migration.Description)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%d\t%-20s\t%s\n", status.Migration.Version, appliedAt, status.Migration.Description)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown migrate action %q, expected up, down or status", action)
+}
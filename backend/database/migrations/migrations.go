@@ -0,0 +1,212 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const migrationsCollection = "schema_migrations"
+
+// ErrIrreversible is returned when rolling back a migration without a Down step
+var ErrIrreversible = errors.New("migration cannot be rolled back")
+
+// Migration is a versioned schema or data change, migrations run in ascending version order
+type Migration struct {
+	Version     int64
+	Description string
+	Up          func(ctx context.Context, database *mongo.Database) error
+	Down        func(ctx context.Context, database *mongo.Database) error
+}
+
+// Record is the document stored in schema_migrations for every applied migration
+type Record struct {
+	Version     int64     `json:"version" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"appliedAt"`
+}
+
+// Status reports whether a known migration has been applied
+type Status struct {
+	Migration Migration
+	AppliedAt *time.Time
+}
+
+var registry []Migration
+
+// register adds a migration to the registry, it is called from the init of every migration file
+func register(migration Migration) {
+	for _, existing := range registry {
+		if existing.Version == migration.Version {
+			panic(fmt.Sprintf("migrations: duplicate version %d", migration.Version))
+		}
+	}
+
+	registry = append(registry, migration)
+	sort.Slice(registry, func(i, j int) bool {
+		return registry[i].Version < registry[j].Version
+	})
+}
+
+type Migrator struct {
+	database   *mongo.Database
+	collection *mongo.Collection
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator running the registered migrations against the given database
+func NewMigrator(database *mongo.Database) *Migrator {
+	return &Migrator{
+		database:   database,
+		collection: database.Collection(migrationsCollection),
+		migrations: registry,
+	}
+}
+
+// Up applies every pending migration in order and returns the ones it applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.database); err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		_, err := m.collection.InsertOne(ctx, Record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down rolls back the given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, ErrIrreversible)
+		}
+		if err := migration.Down(ctx, m.database); err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		if _, err := m.collection.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status lists every known migration with the time it was applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// applied loads the applied migrations keyed by version
+func (m *Migrator) applied(ctx context.Context) (map[int64]Record, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := map[int64]Record{}
+	for cursor.Next(ctx) {
+		var record Record
+		if err := cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+		records[record.Version] = record
+	}
+	return records, cursor.Err()
+}
+
+// checkUnique fails with the duplicated values of field among the documents matching filter and their IDs,
+// so an operator can fix them before a unique index on field is built
+func checkUnique(ctx context.Context, collection *mongo.Collection, field string, filter bson.M) error {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "ids": bson.M{"$push": "$_id"}, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	duplicates := []string{}
+	for cursor.Next(ctx) {
+		var group struct {
+			Value interface{}   `bson:"_id"`
+			IDs   []interface{} `bson:"ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return err
+		}
+
+		ids := make([]string, len(group.IDs))
+		for i, id := range group.IDs {
+			if objectID, ok := id.(primitive.ObjectID); ok {
+				ids[i] = objectID.Hex()
+			} else {
+				ids[i] = fmt.Sprint(id)
+			}
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%v (%s)", group.Value, strings.Join(ids, ", ")))
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%s %s is not unique, fix these values and IDs before migrating: %s", collection.Name(), field, strings.Join(duplicates, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuthorIdentifiers links an author to the authority files that describe them
+type AuthorIdentifiers struct {
+	ISNI     string `json:"isni,omitempty" bson:"isni,omitempty"`
+	VIAF     string `json:"viaf,omitempty" bson:"viaf,omitempty"`
+	ORCID    string `json:"orcid,omitempty" bson:"orcid,omitempty"`
+	Wikidata string `json:"wikidata,omitempty" bson:"wikidata,omitempty"`
+}
+
+// Author is a person credited on books. Variants are the other spellings of the name found on books,
+// such as "Rowling, J. K." for "J.K. Rowling".
+type Author struct {
+	Name        string            `json:"name" bson:"name" validate:"required"`
+	Variants    []string          `json:"variants" bson:"variants"`
+	Biography   string            `json:"biography" bson:"biography"`
+	BirthYear   *int              `json:"birthYear,omitempty" bson:"birthYear,omitempty"`
+	DeathYear   *int              `json:"deathYear,omitempty" bson:"deathYear,omitempty"`
+	Identifiers AuthorIdentifiers `json:"identifiers" bson:"identifiers"`
+}
+
+// Authors is an author as stored, NameKeys holds the normalized name and variants used for matching
+type Authors struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"`
+	Variants    []string           `json:"variants" bson:"variants"`
+	Biography   string             `json:"biography" bson:"biography"`
+	BirthYear   *int               `json:"birthYear,omitempty" bson:"birthYear,omitempty"`
+	DeathYear   *int               `json:"deathYear,omitempty" bson:"deathYear,omitempty"`
+	Identifiers AuthorIdentifiers  `json:"identifiers" bson:"identifiers"`
+	NameKeys    []string           `json:"-" bson:"nameKeys"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// Authors returns the author as stored with the given ID, the server managed fields are left empty
+func (a Author) Authors(id primitive.ObjectID) Authors {
+	return Authors{
+		ID:          id,
+		Name:        a.Name,
+		Variants:    a.Variants,
+		Biography:   a.Biography,
+		BirthYear:   a.BirthYear,
+		DeathYear:   a.DeathYear,
+		Identifiers: a.Identifiers,
+	}
+}
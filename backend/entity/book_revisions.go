@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * actions recorded in the book history
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+	RevisionRevert  = "revert"
+)
+
+// BookRevision is an immutable record of a single change to a book, Revision equals the book version
+// after the change and Book holds the editable fields as they were saved
+type BookRevision struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BookID    primitive.ObjectID `json:"bookId" bson:"bookId"`
+	Revision  int64              `json:"revision" bson:"revision"`
+	Action    string             `json:"action" bson:"action"`
+	UserID    string             `json:"userId,omitempty" bson:"userId,omitempty"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	Changes   []FieldChange      `json:"changes" bson:"changes"`
+	Book      Book               `json:"book" bson:"book"`
+}
+
+// FieldChange is the old and new value of a single book field, keyed by its JSON name
+type FieldChange struct {
+	Field string      `json:"field" bson:"field"`
+	From  interface{} `json:"from" bson:"from"`
+	To    interface{} `json:"to" bson:"to"`
+}
@@ -1,32 +1,122 @@
 package entity
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Book struct {
-	Title         string `json:"title" bson:"title" validate:"required"`
-	Author        string `json:"author" bson:"author" validate:"required"`
-	Year          int    `json:"year" bson:"year" validate:"required"`
-	ISBN          string `json:"isbn" bson:"isbn"`
-	Genre         string `json:"genre" bson:"genre"`
-	Description   string `json:"description" bson:"description"`
-	CoverImageUrl string `json:"coverImageUrl" bson:"coverImageUrl"`
-	CreatedAt     string `json:"createdAt" bson:"createdAt"`
-	UpdatedAt     string `json:"updatedAt" bson:"updatedAt"`
+	Title         string               `json:"title" bson:"title" validate:"required"`
+	Author        string               `json:"author" bson:"author" validate:"required"`
+	AuthorIDs     []primitive.ObjectID `json:"authorIds,omitempty" bson:"authorIds,omitempty"`
+	Year          int                  `json:"year" bson:"year" validate:"required"`
+	ISBN          string               `json:"isbn" bson:"isbn" validate:"omitempty,isbn"`
+	OriginalISBN  string               `json:"originalIsbn,omitempty" bson:"originalIsbn,omitempty"`
+	WorkID        *primitive.ObjectID  `json:"workId,omitempty" bson:"workId,omitempty"`
+	Language      string               `json:"language,omitempty" bson:"language,omitempty" validate:"omitempty,bcp47_language_tag"`
+	Edition       string               `json:"edition,omitempty" bson:"edition,omitempty"`
+	Genre         string               `json:"genre" bson:"genre"`
+	Description   string               `json:"description" bson:"description"`
+	CoverImageUrl string               `json:"coverImageUrl" bson:"coverImageUrl"`
+	CreatedAt     time.Time            `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     *time.Time           `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
 }
 
 type Books struct {
-	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title         string             `json:"title" bson:"title" validate:"required"`
-	Author        string             `json:"author" bson:"author" validate:"required"`
-	Year          int                `json:"year" bson:"year" validate:"required"`
-	ISBN          string             `json:"isbn" bson:"isbn"`
-	Genre         string             `json:"genre" bson:"genre"`
-	Description   string             `json:"description" bson:"description"`
-	CoverImageUrl string             `json:"coverImageUrl" bson:"coverImageUrl"`
-	CreatedAt     string             `json:"createdAt" bson:"createdAt"`
-	UpdatedAt     string             `json:"updatedAt" bson:"updatedAt"`
+	ID            primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title         string               `json:"title" bson:"title" validate:"required"`
+	Author        string               `json:"author" bson:"author" validate:"required"`
+	AuthorIDs     []primitive.ObjectID `json:"authorIds,omitempty" bson:"authorIds,omitempty"`
+	Year          int                  `json:"year" bson:"year" validate:"required"`
+	ISBN          string               `json:"isbn" bson:"isbn" validate:"omitempty,isbn"`
+	OriginalISBN  string               `json:"originalIsbn,omitempty" bson:"originalIsbn,omitempty"`
+	WorkID        *primitive.ObjectID  `json:"workId,omitempty" bson:"workId,omitempty"`
+	Language      string               `json:"language,omitempty" bson:"language,omitempty"`
+	Edition       string               `json:"edition,omitempty" bson:"edition,omitempty"`
+	Genre         string               `json:"genre" bson:"genre"`
+	Description   string               `json:"description" bson:"description"`
+	CoverImageUrl string               `json:"coverImageUrl" bson:"coverImageUrl"`
+	CreatedAt     time.Time            `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     *time.Time           `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	DeletedAt     *time.Time           `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	Version       int64                `json:"version" bson:"version"`
+	Rating        *RatingSummary       `json:"rating,omitempty" bson:"rating,omitempty"`
+	Availability  *CopyAvailability    `json:"availability,omitempty" bson:"-"`
+	EditionCount  int                  `json:"editionCount,omitempty" bson:"-"`
+	OtherEditions []Books              `json:"otherEditions,omitempty" bson:"-"`
+	NextInSeries  []SeriesNext         `json:"nextInSeries,omitempty" bson:"-"`
+}
+
+// Books returns the book as stored with the given ID, the server managed fields are left empty
+func (b Book) Books(id primitive.ObjectID) Books {
+	return Books{
+		ID:            id,
+		Title:         b.Title,
+		Author:        b.Author,
+		AuthorIDs:     b.AuthorIDs,
+		Year:          b.Year,
+		ISBN:          b.ISBN,
+		OriginalISBN:  b.OriginalISBN,
+		WorkID:        b.WorkID,
+		Language:      b.Language,
+		Edition:       b.Edition,
+		Genre:         b.Genre,
+		Description:   b.Description,
+		CoverImageUrl: b.CoverImageUrl,
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+	}
+}
+
+// Book returns the client editable fields of a stored book
+func (b Books) Book() Book {
+	return Book{
+		Title:         b.Title,
+		Author:        b.Author,
+		AuthorIDs:     b.AuthorIDs,
+		Year:          b.Year,
+		ISBN:          b.ISBN,
+		OriginalISBN:  b.OriginalISBN,
+		WorkID:        b.WorkID,
+		Language:      b.Language,
+		Edition:       b.Edition,
+		Genre:         b.Genre,
+		Description:   b.Description,
+		CoverImageUrl: b.CoverImageUrl,
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+	}
+}
+
+// BookSearchResult is a book matched by a full-text search, highlights are keyed by field name
+type BookSearchResult struct {
+	Books
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// * struct for bulk import report
+const (
+	ImportAccepted  = "accepted"
+	ImportRejected  = "rejected"
+	ImportDuplicate = "duplicate"
+)
+
+type ImportRow struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	ISBN   string `json:"isbn,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun     bool        `json:"dryRun"`
+	Total      int         `json:"total"`
+	Accepted   int         `json:"accepted"`
+	Rejected   int         `json:"rejected"`
+	Duplicates int         `json:"duplicates"`
+	Rows       []ImportRow `json:"rows"`
 }
 
 // * struct for url processing
@@ -34,7 +124,7 @@ type URL struct {
 	URL       string      `json:"url" bson:"url"`
 	Operation string      `json:"operation" bson:"operation"`
 	Response  URLResponse `json:"response" bson:"response"`
-	CreatedAt string      `json:"createdAt" bson:"createdAt"`
+	CreatedAt time.Time   `json:"createdAt" bson:"createdAt"`
 }
 
 type URLRequest struct {
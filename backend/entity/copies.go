@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * status of a physical copy
+const (
+	CopyAvailable = "available"
+	CopyOnLoan    = "on_loan"
+	CopyInRepair  = "in_repair"
+	CopyLost      = "lost"
+	// CopyOnHold is a returned copy set aside for the next patron in the hold queue
+	CopyOnHold = "on_hold"
+)
+
+// InCirculation reports whether a copy status is managed by loans and holds rather than edited directly
+func InCirculation(status string) bool {
+	return status == CopyOnLoan || status == CopyOnHold
+}
+
+// Copy is a physical copy of a book, identified by the barcode on its label.
+// Material is the kind of item (book, dvd, magazine...) and selects the fine policy of its loans.
+type Copy struct {
+	Barcode    string     `json:"barcode" bson:"barcode" validate:"required"`
+	Branch     string     `json:"branch" bson:"branch" validate:"required"`
+	Shelf      string     `json:"shelf" bson:"shelf"`
+	Material   string     `json:"material,omitempty" bson:"material,omitempty"`
+	Status     string     `json:"status" bson:"status" validate:"omitempty,oneof=available on_loan on_hold in_repair lost"`
+	AcquiredAt *time.Time `json:"acquiredAt,omitempty" bson:"acquiredAt,omitempty"`
+	Price      float64    `json:"price" bson:"price" validate:"gte=0"`
+}
+
+type Copies struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BookID     primitive.ObjectID `json:"bookId" bson:"bookId"`
+	Barcode    string             `json:"barcode" bson:"barcode"`
+	Branch     string             `json:"branch" bson:"branch"`
+	Shelf      string             `json:"shelf" bson:"shelf"`
+	Material   string             `json:"material,omitempty" bson:"material,omitempty"`
+	Status     string             `json:"status" bson:"status"`
+	AcquiredAt *time.Time         `json:"acquiredAt,omitempty" bson:"acquiredAt,omitempty"`
+	Price      float64            `json:"price" bson:"price"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// Copies returns the copy as stored with the given IDs
+func (c Copy) Copies(id, bookID primitive.ObjectID) Copies {
+	return Copies{
+		ID:         id,
+		BookID:     bookID,
+		Barcode:    c.Barcode,
+		Branch:     c.Branch,
+		Shelf:      c.Shelf,
+		Material:   c.Material,
+		Status:     c.Status,
+		AcquiredAt: c.AcquiredAt,
+		Price:      c.Price,
+	}
+}
+
+// CopyAvailability counts the copies of a book by status
+type CopyAvailability struct {
+	Total     int `json:"total" bson:"total"`
+	Available int `json:"available" bson:"available"`
+	OnLoan    int `json:"onLoan" bson:"onLoan"`
+	OnHold    int `json:"onHold" bson:"onHold"`
+	InRepair  int `json:"inRepair" bson:"inRepair"`
+	Lost      int `json:"lost" bson:"lost"`
+}
+
+// Add counts one more copy with the given status
+func (a *CopyAvailability) Add(status string) {
+	a.Total++
+	switch status {
+	case CopyAvailable:
+		a.Available++
+	case CopyOnLoan:
+		a.OnLoan++
+	case CopyOnHold:
+		a.OnHold++
+	case CopyInRepair:
+		a.InRepair++
+	case CopyLost:
+		a.Lost++
+	}
+}
@@ -0,0 +1,12 @@
+package entity
+
+// Cover is an uploaded cover image. URL is where the API serves it and becomes the coverImageUrl of the book,
+// Thumbnails maps the name of each generated size to its URL.
+type Cover struct {
+	URL         string            `json:"url"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * type of a fine ledger entry made by a librarian
+const (
+	FineWaive  = "waive"
+	FineAdjust = "adjust"
+)
+
+// FineRequest waives or adjusts the fine balance of a user. A waived amount must be positive,
+// an adjustment adds a positive or negative amount. LoanID optionally ties the entry to a loan.
+type FineRequest struct {
+	Amount float64 `json:"amount" validate:"required"`
+	Reason string  `json:"reason" validate:"required"`
+	LoanID string  `json:"loanId"`
+}
+
+// FineEntries is a change to the fine balance of a user made by a librarian, Amount is signed
+type FineEntries struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID    string              `json:"userId" bson:"userId"`
+	Type      string              `json:"type" bson:"type"`
+	Amount    float64             `json:"amount" bson:"amount"`
+	Reason    string              `json:"reason" bson:"reason"`
+	LoanID    *primitive.ObjectID `json:"loanId,omitempty" bson:"loanId,omitempty"`
+	CreatedBy string              `json:"createdBy" bson:"createdBy"`
+	CreatedAt time.Time           `json:"createdAt" bson:"createdAt"`
+}
+
+// LoanFine is the late fee of a loan, charged when the loan was returned or still accruing while it is out
+type LoanFine struct {
+	LoanID      primitive.ObjectID `json:"loanId"`
+	BookID      primitive.ObjectID `json:"bookId"`
+	Material    string             `json:"material,omitempty"`
+	DueAt       time.Time          `json:"dueAt"`
+	ReturnedAt  *time.Time         `json:"returnedAt,omitempty"`
+	DaysOverdue int                `json:"daysOverdue"`
+	Amount      float64            `json:"amount"`
+	Accruing    bool               `json:"accruing"`
+}
+
+// FineAccount is the fine balance of a user: the fines charged on returned loans, the fines accruing
+// on overdue loans and the librarian adjustments
+type FineAccount struct {
+	UserID   string     `json:"userId"`
+	Balance  float64    `json:"balance"`
+	Charged  float64    `json:"charged"`
+	Accruing float64    `json:"accruing"`
+	Adjusted float64    `json:"adjusted"`
+	Loans    []LoanFine `json:"loans"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Genre is a node of the genre taxonomy. The slug identifies it and is what books store as their genre,
+// Parent is the slug of the broader genre and Labels holds the display name per language tag.
+type Genre struct {
+	Slug   string            `json:"slug" bson:"_id" validate:"required,genre_slug"`
+	Parent string            `json:"parent,omitempty" bson:"parent,omitempty" validate:"omitempty,genre_slug"`
+	Labels map[string]string `json:"labels" bson:"labels" validate:"required,dive,keys,bcp47_language_tag,endkeys,required"`
+}
+
+// Genres is a genre as stored, Label is the label in the language of the request
+type Genres struct {
+	Slug      string            `json:"slug" bson:"_id"`
+	Parent    string            `json:"parent,omitempty" bson:"parent,omitempty"`
+	Labels    map[string]string `json:"labels" bson:"labels"`
+	Label     string            `json:"label" bson:"-"`
+	CreatedAt time.Time         `json:"createdAt" bson:"createdAt"`
+	UpdatedAt *time.Time        `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// Genres returns the genre as stored, the server managed fields are left empty
+func (g Genre) Genres() Genres {
+	return Genres{
+		Slug:   g.Slug,
+		Parent: g.Parent,
+		Labels: g.Labels,
+	}
+}
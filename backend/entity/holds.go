@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * status of a hold, waiting holds form a first in first out queue per book
+const (
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldExpired   = "expired"
+	HoldCancelled = "cancelled"
+)
+
+// HoldRequest places a hold on a book
+type HoldRequest struct {
+	BookID string `json:"bookId" validate:"required"`
+}
+
+// Holds is a patron waiting for a copy of a book. A ready hold has a copy set aside until PickupBy,
+// Position is the place in the queue of a waiting hold and is not stored.
+type Holds struct {
+	ID       primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID   string              `json:"userId" bson:"userId"`
+	BookID   primitive.ObjectID  `json:"bookId" bson:"bookId"`
+	Status   string              `json:"status" bson:"status"`
+	PlacedAt time.Time           `json:"placedAt" bson:"placedAt"`
+	CopyID   *primitive.ObjectID `json:"copyId,omitempty" bson:"copyId,omitempty"`
+	ReadyAt  *time.Time          `json:"readyAt,omitempty" bson:"readyAt,omitempty"`
+	PickupBy *time.Time          `json:"pickupBy,omitempty" bson:"pickupBy,omitempty"`
+	ClosedAt *time.Time          `json:"closedAt,omitempty" bson:"closedAt,omitempty"`
+	Position int64               `json:"position,omitempty" bson:"-"`
+}
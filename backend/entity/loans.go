@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * status of a loan
+const (
+	LoanActive   = "active"
+	LoanReturned = "returned"
+)
+
+// LoanRequest checks out a copy by its ID or by the barcode on its label
+type LoanRequest struct {
+	CopyID  string `json:"copyId" validate:"required_without=Barcode"`
+	Barcode string `json:"barcode" validate:"required_without=CopyID"`
+}
+
+// Loans is a copy lent to a user, Renewals counts how many times the due date was extended.
+// Material is copied from the copy at checkout, Fine is the late fee charged when the loan was returned.
+type Loans struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID       string             `json:"userId" bson:"userId"`
+	BookID       primitive.ObjectID `json:"bookId" bson:"bookId"`
+	CopyID       primitive.ObjectID `json:"copyId" bson:"copyId"`
+	Material     string             `json:"material,omitempty" bson:"material,omitempty"`
+	Status       string             `json:"status" bson:"status"`
+	CheckedOutAt time.Time          `json:"checkedOutAt" bson:"checkedOutAt"`
+	DueAt        time.Time          `json:"dueAt" bson:"dueAt"`
+	ReturnedAt   *time.Time         `json:"returnedAt,omitempty" bson:"returnedAt,omitempty"`
+	Renewals     int                `json:"renewals" bson:"renewals"`
+	Fine         float64            `json:"fine,omitempty" bson:"fine,omitempty"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScoredBook is a book picked by the recommendations job, a higher score is a closer match
+type ScoredBook struct {
+	BookID primitive.ObjectID `json:"bookId" bson:"bookId"`
+	Score  float64            `json:"score" bson:"score"`
+}
+
+// Recommendations are the books scored for a book or a user by the last refresh, best match first
+type Recommendations struct {
+	Books      []ScoredBook `json:"books" bson:"books"`
+	ComputedAt time.Time    `json:"computedAt" bson:"computedAt"`
+}
+
+// RecommendedBook is a recommended book with the score it was picked with
+type RecommendedBook struct {
+	Books
+	Score float64 `json:"score"`
+}
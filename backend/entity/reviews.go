@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Review rates a book from 1 to 5 stars with an optional text, a user reviews a book once
+type Review struct {
+	Rating int    `json:"rating" bson:"rating" validate:"required,min=1,max=5"`
+	Text   string `json:"text" bson:"text" validate:"max=5000"`
+}
+
+type Reviews struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BookID    primitive.ObjectID `json:"bookId" bson:"bookId"`
+	UserID    string             `json:"userId" bson:"userId"`
+	Rating    int                `json:"rating" bson:"rating"`
+	Text      string             `json:"text" bson:"text"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// RatingSummary aggregates the ratings of a book and is kept on the book by every review write.
+// Histogram counts the ratings by stars, Histogram[0] being the one star ratings.
+type RatingSummary struct {
+	Average   float64  `json:"average" bson:"average"`
+	Count     int64    `json:"count" bson:"count"`
+	Sum       int64    `json:"-" bson:"sum"`
+	Histogram [5]int64 `json:"histogram" bson:"histogram"`
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// * kind of a shelf, every user has the three reading status shelves and adds custom shelves
+const (
+	ShelfWantToRead = "want_to_read"
+	ShelfReading    = "reading"
+	ShelfRead       = "read"
+	ShelfCustom     = "custom"
+)
+
+// DefaultShelves are created for every user in this order, a book is on at most one of them
+var DefaultShelves = []Shelves{
+	{Kind: ShelfWantToRead, Name: "Want to Read"},
+	{Kind: ShelfReading, Name: "Reading"},
+	{Kind: ShelfRead, Name: "Read"},
+}
+
+// Shelf is a reading list of a user, a public shelf can be read by every signed in user
+type Shelf struct {
+	Name   string `json:"name" bson:"name" validate:"required,max=100"`
+	Public bool   `json:"public" bson:"public"`
+}
+
+// Shelves is a shelf as stored, BookCount is the number of books on it and is not stored
+type Shelves struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    string             `json:"userId" bson:"userId"`
+	Kind      string             `json:"kind" bson:"kind"`
+	Name      string             `json:"name" bson:"name"`
+	Public    bool               `json:"public" bson:"public"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	BookCount int64              `json:"bookCount" bson:"-"`
+}
+
+// ShelfItem puts a book on a shelf or changes its reading dates and notes, BookID is only read when adding
+type ShelfItem struct {
+	BookID     string     `json:"bookId,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Notes      string     `json:"notes" validate:"max=2000"`
+}
+
+// ShelfItems is a book on a shelf, Book is the current book record and is not stored
+type ShelfItems struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ShelfID    primitive.ObjectID `json:"shelfId" bson:"shelfId"`
+	BookID     primitive.ObjectID `json:"bookId" bson:"bookId"`
+	AddedAt    time.Time          `json:"addedAt" bson:"addedAt"`
+	StartedAt  *time.Time         `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+	Notes      string             `json:"notes" bson:"notes"`
+	UpdatedAt  *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	Book       *Books             `json:"book,omitempty" bson:"-"`
+}
@@ -4,6 +4,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// * role of a staff user, patrons have no role. Roles are granted in the database, not at registration.
+const (
+	RoleLibrarian = "librarian"
+	RoleAdmin     = "admin"
+)
+
 // User model
 type User struct {
 	ID       string `json:"id" bson:"_id"`
@@ -11,10 +17,12 @@ type User struct {
 	Name     string `json:"name" bson:"name" validate:"required"`
 	Username string `json:"username" bson:"username" validate:"required"`
 	Password string `json:"password,omitempty" bson:"password" validate:"required"`
+	Role     string `json:"role,omitempty" bson:"role,omitempty"`
 }
 
 // JWTClaims represents the claims of JWT
 type JWTClaims struct {
-	ID string `json:"id"`
+	ID   string `json:"id"`
+	Role string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SeriesEntry places a work in a series. Position orders the series and may be fractional,
+// such as 2.5 for a novella published between the second and third volume.
+type SeriesEntry struct {
+	SeriesID primitive.ObjectID `json:"seriesId" bson:"seriesId"`
+	Position float64            `json:"position" bson:"position" validate:"gt=0"`
+}
+
+// Work is the creation shared by the editions and translations of a book, each edition is a book
+// record with the ID of its work. Series lists the numbered series the work belongs to.
+type Work struct {
+	Title            string               `json:"title" bson:"title" validate:"required"`
+	AuthorIDs        []primitive.ObjectID `json:"authorIds" bson:"authorIds"`
+	OriginalLanguage string               `json:"originalLanguage,omitempty" bson:"originalLanguage,omitempty" validate:"omitempty,bcp47_language_tag"`
+	FirstPublished   int                  `json:"firstPublished,omitempty" bson:"firstPublished,omitempty"`
+	Description      string               `json:"description" bson:"description"`
+	Series           []SeriesEntry        `json:"series" bson:"series" validate:"dive"`
+}
+
+type Works struct {
+	ID               primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title            string               `json:"title" bson:"title"`
+	AuthorIDs        []primitive.ObjectID `json:"authorIds" bson:"authorIds"`
+	OriginalLanguage string               `json:"originalLanguage,omitempty" bson:"originalLanguage,omitempty"`
+	FirstPublished   int                  `json:"firstPublished,omitempty" bson:"firstPublished,omitempty"`
+	Description      string               `json:"description" bson:"description"`
+	Series           []SeriesEntry        `json:"series" bson:"series"`
+	CreatedAt        time.Time            `json:"createdAt" bson:"createdAt"`
+	UpdatedAt        *time.Time           `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// Works returns the work as stored with the given ID, the server managed fields are left empty
+func (w Work) Works(id primitive.ObjectID) Works {
+	return Works{
+		ID:               id,
+		Title:            w.Title,
+		AuthorIDs:        w.AuthorIDs,
+		OriginalLanguage: w.OriginalLanguage,
+		FirstPublished:   w.FirstPublished,
+		Description:      w.Description,
+		Series:           w.Series,
+	}
+}
+
+// Position returns the position of the work in a series and whether the work belongs to it
+func (w Works) Position(seriesID primitive.ObjectID) (float64, bool) {
+	for _, entry := range w.Series {
+		if entry.SeriesID == seriesID {
+			return entry.Position, true
+		}
+	}
+	return 0, false
+}
+
+// Series is a numbered sequence of works, such as the volumes of a saga
+type Series struct {
+	Title       string `json:"title" bson:"title" validate:"required"`
+	Description string `json:"description" bson:"description"`
+}
+
+// SeriesRecord is a series as stored
+type SeriesRecord struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title       string             `json:"title" bson:"title"`
+	Description string             `json:"description" bson:"description"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+// SeriesWork is a work of a series at its position
+type SeriesWork struct {
+	Position float64 `json:"position"`
+	Works
+}
+
+// SeriesNext points from a book to the work that follows its work in a series. BookID is an edition
+// of that work, in the language of the book when there is one.
+type SeriesNext struct {
+	SeriesID primitive.ObjectID  `json:"seriesId"`
+	Series   string              `json:"series"`
+	Position float64             `json:"position"`
+	WorkID   primitive.ObjectID  `json:"workId"`
+	Title    string              `json:"title"`
+	BookID   *primitive.ObjectID `json:"bookId,omitempty"`
+}
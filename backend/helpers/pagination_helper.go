@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ErrInvalidPagination is returned when the page or limit query parameter is not a valid number
+var ErrInvalidPagination = errors.New("invalid pagination query")
+
+// ParsePagination reads the page and limit query parameters, falling back to the first page of DefaultPageLimit
+func ParsePagination(ctx *gin.Context) (int64, int64, error) {
+	page, limit := int64(1), int64(DefaultPageLimit)
+
+	if value := ctx.Query("page"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			return page, limit, ErrInvalidPagination
+		}
+		page = parsed
+	}
+
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 || parsed > MaxPageLimit {
+			return page, limit, ErrInvalidPagination
+		}
+		limit = parsed
+	}
+
+	return page, limit, nil
+}
@@ -11,9 +11,22 @@ type Response struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// Pagination is the meta of a paginated list response
+type Pagination struct {
+	Page       int64  `json:"page,omitempty"`
+	Limit      int64  `json:"limit"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 func Success(ctx *gin.Context, code int, message string, data interface{}) {
+	SuccessWithMeta(ctx, code, message, data, nil)
+}
+
+func SuccessWithMeta(ctx *gin.Context, code int, message string, data interface{}, meta interface{}) {
 	language := ctx.Query("lang")
 	localizeMessage := ""
 
@@ -28,6 +41,7 @@ func Success(ctx *gin.Context, code int, message string, data interface{}) {
 		Code:    code,
 		Message: localizeMessage,
 		Data:    data,
+		Meta:    meta,
 	})
 }
 
@@ -65,6 +79,108 @@ func NotFound(ctx *gin.Context, code int, message string) {
 	})
 }
 
+func Conflict(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusConflict, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
+func Forbidden(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusForbidden, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
+func PreconditionFailed(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusPreconditionFailed, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
+func PreconditionRequired(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusPreconditionRequired, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
+func UnsupportedMediaType(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusUnsupportedMediaType, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
+func PayloadTooLarge(ctx *gin.Context, code int, message string) {
+	language := ctx.Query("lang")
+	localizeMessage := ""
+
+	if language != "" {
+		langLocalize := utils.GetLocalizer(language)
+		localizeMessage = utils.LocalizeString(langLocalize, message, map[string]interface{}{})
+	} else {
+		localizeMessage = utils.LocalizeStringMessage(ctx, message)
+	}
+
+	ctx.JSON(http.StatusRequestEntityTooLarge, Response{
+		Code:    code,
+		Message: localizeMessage,
+	})
+}
+
 func ServerError(ctx *gin.Context, code int, message string) {
 	language := ctx.Query("lang")
 	localizeMessage := ""
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"library-books/repository"
+	"log"
+	"time"
+)
+
+// StartHoldExpiry expires the ready holds not picked up in time and passes their copies to the next hold,
+// which gets pickupWindow to collect it, checking every interval until ctx is done
+func StartHoldExpiry(ctx context.Context, holds repository.HoldRepository, pickupWindow time.Duration, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			now := time.Now().UTC()
+			expired, err := holds.Expire(ctx, now, now.Add(pickupWindow))
+			if err != nil {
+				log.Println("expire holds:", err)
+			} else if expired > 0 {
+				log.Printf("Expired %d holds", expired)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
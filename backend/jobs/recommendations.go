@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"library-books/entity"
+	"library-books/repository"
+	"library-books/services"
+	"log"
+	"time"
+)
+
+// StartRecommendations scores the similar books of every book and the recommendations of every reader
+// from the catalogue, the shelves and the loans, refreshing every interval until ctx is done
+func StartRecommendations(ctx context.Context, books repository.BookRepository, shelves repository.ShelfRepository, loans repository.LoanRepository, recommendations repository.RecommendationRepository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			scoredBooks, scoredUsers, err := refreshRecommendations(ctx, books, shelves, loans, recommendations)
+			if err != nil {
+				log.Println("refresh recommendations:", err)
+			} else {
+				log.Printf("Refreshed recommendations of %d books and %d users", scoredBooks, scoredUsers)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// refreshRecommendations computes and stores a new set of scores, it returns the number of books and users scored
+func refreshRecommendations(ctx context.Context, books repository.BookRepository, shelves repository.ShelfRepository, loans repository.LoanRepository, recommendations repository.RecommendationRepository) (int, int, error) {
+	computedAt := time.Now().UTC()
+
+	catalogue := []entity.Books{}
+	err := books.Stream(ctx, repository.BookFilter{}, nil, func(book entity.Books) error {
+		catalogue = append(catalogue, book)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	recommender := services.NewRecommender(catalogue)
+	err = shelves.StreamShelvings(ctx, func(shelving repository.Shelving) error {
+		recommender.AddShelving(shelving.UserID, shelving.ShelfID, shelving.BookID)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = loans.Stream(ctx, func(loan entity.Loans) error {
+		recommender.AddBorrowing(loan.UserID, loan.BookID)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	similar := recommender.Similar()
+	if err := recommendations.ReplaceSimilar(ctx, similar, computedAt); err != nil {
+		return 0, 0, err
+	}
+	users := recommender.ForUsers(similar)
+	if err := recommendations.ReplaceForUsers(ctx, users, computedAt); err != nil {
+		return 0, 0, err
+	}
+	return len(similar), len(users), nil
+}
@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"library-books/repository"
+	"library-books/services"
+	"library-books/storage"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrashDependents are the stores holding data about books, a purged book is removed from all of them
+type TrashDependents struct {
+	Transactor      repository.Transactor
+	Copies          repository.CopyRepository
+	Loans           repository.LoanRepository
+	Holds           repository.HoldRepository
+	Reviews         repository.ReviewRepository
+	Revisions       repository.RevisionRepository
+	Shelves         repository.ShelfRepository
+	Recommendations repository.RecommendationRepository
+	Covers          storage.BlobStore
+	CoverCache      storage.BlobStore
+}
+
+// StartTrashPurge permanently deletes the books that stayed in the trash longer than retention,
+// checking every interval until ctx is done. The idle copies and returned loans of a book go with it,
+// loans that charged a fine are kept for the fine balance. A book with a copy still on loan or on hold
+// stays in the trash until the copy comes back.
+func StartTrashPurge(ctx context.Context, books repository.BookRepository, dependents TrashDependents, retention time.Duration, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purged, kept, err := purgeTrash(ctx, books, dependents, time.Now().UTC().Add(-retention))
+			if err != nil {
+				log.Println("purge trash:", err)
+			}
+			if purged > 0 || kept > 0 {
+				log.Printf("Purged %d books from the trash, kept %d with copies on loan or on hold", purged, kept)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// purgeTrash purges the books deleted before cutoff, it returns the number of books purged and kept
+func purgeTrash(ctx context.Context, books repository.BookRepository, dependents TrashDependents, cutoff time.Time) (int, int, error) {
+	ids, err := books.ExpiredTrash(ctx, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	purged, kept := 0, 0
+	for _, id := range ids {
+		availability, err := dependents.Copies.Availability(ctx, []primitive.ObjectID{id})
+		if err != nil {
+			return purged, kept, err
+		}
+		if counts := availability[id]; counts.OnLoan > 0 || counts.OnHold > 0 {
+			kept++
+			continue
+		}
+
+		// the book goes first, a book restored meanwhile aborts the transaction before its data is touched
+		err = dependents.Transactor.Transaction(ctx, func(ctx context.Context) error {
+			if err := books.Purge(ctx, id, cutoff); err != nil {
+				return err
+			}
+			if err := dependents.Copies.DeleteByBook(ctx, id); err != nil {
+				return err
+			}
+			if err := dependents.Loans.DeleteReturnedByBook(ctx, id); err != nil {
+				return err
+			}
+			if err := dependents.Holds.DeleteByBook(ctx, id); err != nil {
+				return err
+			}
+			if err := dependents.Reviews.DeleteByBook(ctx, id); err != nil {
+				return err
+			}
+			if err := dependents.Revisions.DeleteByBook(ctx, id); err != nil {
+				return err
+			}
+			if err := dependents.Shelves.RemoveBook(ctx, id); err != nil {
+				return err
+			}
+			return dependents.Recommendations.RemoveBook(ctx, id)
+		})
+		if err == repository.ErrBookNotInTrash {
+			continue
+		}
+		if err != nil {
+			return purged, kept, err
+		}
+		purged++
+
+		// blobs are not transactional, a failed delete only leaves unreachable files behind
+		for _, store := range []storage.BlobStore{dependents.Covers, dependents.CoverCache} {
+			if err := store.DeletePrefix(ctx, services.CoverPrefix(id.Hex())); err != nil {
+				log.Println("purge trash: delete cover:", err)
+			}
+		}
+	}
+	return purged, kept, nil
+}
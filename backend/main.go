@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
 	"library-books/config"
+	"library-books/database/migrations"
+	"library-books/database/mongodb"
 	"library-books/middleware"
 	"library-books/routes"
+	"library-books/utils"
+	"log"
+	"os"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -22,6 +28,9 @@ var validate *validator.Validate
 // @host localhost:8080
 // @BasePath /api/v1
 // @schemes http
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 
 func main() {
 	/**
@@ -31,11 +40,25 @@ func main() {
 	*   4. Validator
 	**/
 
-	// Initialize the validator
+	// Initialize the validator and register the custom validation tags
 	validate = validator.New()
+	if err := utils.RegisterValidations(validate); err != nil {
+		panic(err)
+	}
 
-	// init config viper and setup router
+	// init config viper
 	config := config.ConfigViper()
+
+	// run schema migrations from the command line: go run main.go migrate [up|down [steps]|status]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		mongodb.Connect()
+		if err := migrations.RunCommand(context.Background(), mongodb.Database, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// setup router
 	router := routes.SetupRouter(validate)
 
 	// server log
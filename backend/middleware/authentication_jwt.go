@@ -15,10 +15,8 @@ func AuthMiddleware() gin.HandlerFunc {
 		config := config.ConfigViper()
 		jwtKeySecret := config.GetString("jwt.key")
 
-		tokenString := ctx.GetHeader("Authorization")
-		jwtString := strings.Split(tokenString, "Bearer ")[1]
-
-		if tokenString == "" {
+		jwtString, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok {
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
 			ctx.Abort()
 			return
@@ -39,3 +37,77 @@ func AuthMiddleware() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// OptionalAuthMiddleware sets the JWT claims when a valid bearer token is sent and lets anonymous
+// requests through, a token that is sent but invalid is still rejected
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		jwtString, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		config := config.ConfigViper()
+		jwtKeySecret := config.GetString("jwt.key")
+
+		token, err := jwt.Parse(jwtString, func(token *jwt.Token) (interface{}, error) {
+			return []byte(jwtKeySecret), nil
+		})
+
+		if err != nil || !token.Valid {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired JWT"})
+			ctx.Abort()
+			return
+		}
+
+		claims := token.Claims.(jwt.MapClaims)
+		ctx.Set("claims", claims)
+		ctx.Next()
+	}
+}
+
+// UserID returns the user ID from the JWT claims set by the auth middlewares, empty for anonymous requests
+func UserID(ctx *gin.Context) string {
+	value, ok := ctx.Get("claims")
+	if !ok {
+		return ""
+	}
+	claims, ok := value.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	id, _ := claims["id"].(string)
+	return id
+}
+
+// RequireRole lets through the requests whose JWT carries one of the given roles,
+// it must run after AuthMiddleware
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := UserRole(ctx)
+		for _, allowed := range roles {
+			if role != "" && role == allowed {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+		ctx.Abort()
+	}
+}
+
+// UserRole returns the staff role from the JWT claims set by the auth middlewares, empty for patrons
+func UserRole(ctx *gin.Context) string {
+	value, ok := ctx.Get("claims")
+	if !ok {
+		return ""
+	}
+	claims, ok := value.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	role, _ := claims["role"].(string)
+	return role
+}
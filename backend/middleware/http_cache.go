@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPCache struct {
+	policies map[string]string
+}
+
+// initialization of http cache middleware, policies map a route path (e.g. /api/v1/books/:id) to its Cache-Control value
+func NewHTTPCache(policies map[string]string) *HTTPCache {
+	normalized := map[string]string{}
+	for path, policy := range policies {
+		normalized[strings.TrimSuffix(path, "/")] = policy
+	}
+	return &HTTPCache{policies: normalized}
+}
+
+// middleware conditional GET: buffers the response of a GET request, adds an ETag when the handler
+// did not set one, answers 304 Not Modified for matching If-None-Match / If-Modified-Since headers
+// and applies the Cache-Control policy configured for the route
+func (h *HTTPCache) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+
+		writer := &bufferedResponseWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		header := ctx.Writer.Header()
+		if writer.status != http.StatusOK {
+			ctx.Writer.WriteHeader(writer.status)
+			ctx.Writer.Write(writer.body.Bytes())
+			return
+		}
+
+		if header.Get("ETag") == "" {
+			sum := sha256.Sum256(writer.body.Bytes())
+			header.Set("ETag", `W/"`+base64.RawURLEncoding.EncodeToString(sum[:16])+`"`)
+		}
+		if policy, ok := h.policies[strings.TrimSuffix(ctx.FullPath(), "/")]; ok {
+			header.Set("Cache-Control", policy)
+		}
+		header.Add("Vary", "Accept-Language")
+
+		if notModified(ctx.Request, header.Get("ETag"), header.Get("Last-Modified")) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			ctx.Writer.WriteHeader(http.StatusNotModified)
+			ctx.Writer.WriteHeaderNow()
+			return
+		}
+
+		ctx.Writer.WriteHeader(writer.status)
+		ctx.Writer.Write(writer.body.Bytes())
+	}
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when If-None-Match is absent (RFC 9110 section 13.2.2)
+func notModified(request *http.Request, etag string, lastModified string) bool {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETag(candidate) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified == "" {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	return err == nil && !modified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// weakETag strips the weak indicator, If-None-Match uses the weak comparison
+func weakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
+
+// bufferedResponseWriter holds the status and body until the middleware decides what to send
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.body.Len() > 0
+}
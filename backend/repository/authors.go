@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"library-books/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrAuthorNotFound is returned when no author matches the requested ID
+var ErrAuthorNotFound = errors.New("author not found")
+
+// AuthorPage is a single page of authors ordered by name
+type AuthorPage struct {
+	Authors []entity.Authors
+	Total   int64
+}
+
+// AuthorRepository stores authors. Names and variants are matched on their normalized form, see utils.AuthorNameKey.
+type AuthorRepository interface {
+	Create(ctx context.Context, author entity.Author) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Authors, error)
+	// List pages through the authors whose name or a variant contains the query, an empty query lists every author
+	List(ctx context.Context, query string, page, limit int64) (AuthorPage, error)
+	// FindByName returns the authors whose name or a variant has the same normalized form as name
+	FindByName(ctx context.Context, name string) ([]entity.Authors, error)
+	// Exists reports whether every given author is stored
+	Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error)
+	Update(ctx context.Context, id primitive.ObjectID, author entity.Author) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// authorNameKeys returns the distinct normalized forms of the name and variants of an author
+func authorNameKeys(author entity.Author) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, name := range append([]string{author.Name}, author.Variants...) {
+		key := utils.AuthorNameKey(name)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
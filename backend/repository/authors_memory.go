@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"library-books/utils"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryAuthorRepository struct {
+	mu      sync.RWMutex
+	authors map[primitive.ObjectID]entity.Authors
+}
+
+// NewMemoryAuthorRepository returns an AuthorRepository that keeps authors in memory, useful for tests and local demos
+func NewMemoryAuthorRepository() AuthorRepository {
+	return &memoryAuthorRepository{authors: map[primitive.ObjectID]entity.Authors{}}
+}
+
+func (r *memoryAuthorRepository) Create(ctx context.Context, author entity.Author) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := author.Authors(primitive.NewObjectID())
+	stored.NameKeys = authorNameKeys(author)
+	stored.CreatedAt = time.Now().UTC()
+	r.authors[stored.ID] = stored
+	return stored.ID, nil
+}
+
+func (r *memoryAuthorRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Authors, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.authors[id]
+	if !ok {
+		return entity.Authors{}, ErrAuthorNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryAuthorRepository) List(ctx context.Context, query string, page, limit int64) (AuthorPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key := utils.AuthorNameKey(query)
+	authors := []entity.Authors{}
+	for _, stored := range r.authors {
+		if key == "" || slices.ContainsFunc(stored.NameKeys, func(nameKey string) bool { return strings.Contains(nameKey, key) }) {
+			authors = append(authors, stored)
+		}
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Name != authors[j].Name {
+			return authors[i].Name < authors[j].Name
+		}
+		return authors[i].ID.Hex() < authors[j].ID.Hex()
+	})
+
+	result := AuthorPage{Authors: []entity.Authors{}, Total: int64(len(authors))}
+	start := (page - 1) * limit
+	if start < int64(len(authors)) {
+		end := min(start+limit, int64(len(authors)))
+		result.Authors = authors[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryAuthorRepository) FindByName(ctx context.Context, name string) ([]entity.Authors, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []entity.Authors{}
+	key := utils.AuthorNameKey(name)
+	if key == "" {
+		return authors, nil
+	}
+	for _, stored := range r.authors {
+		if slices.Contains(stored.NameKeys, key) {
+			authors = append(authors, stored)
+		}
+	}
+	return authors, nil
+}
+
+func (r *memoryAuthorRepository) Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := r.authors[id]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *memoryAuthorRepository) Update(ctx context.Context, id primitive.ObjectID, author entity.Author) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.authors[id]
+	if !ok {
+		return ErrAuthorNotFound
+	}
+
+	updated := author.Authors(id)
+	updated.NameKeys = authorNameKeys(author)
+	updated.CreatedAt = stored.CreatedAt
+	updatedAt := time.Now().UTC()
+	updated.UpdatedAt = &updatedAt
+	r.authors[id] = updated
+	return nil
+}
+
+func (r *memoryAuthorRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.authors[id]; !ok {
+		return ErrAuthorNotFound
+	}
+	delete(r.authors, id)
+	return nil
+}
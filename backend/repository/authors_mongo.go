@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"library-books/utils"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const authorsCollection = "authors"
+
+type mongoAuthorRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAuthorRepository returns an AuthorRepository backed by the authors collection of the given database
+func NewMongoAuthorRepository(database *mongo.Database) AuthorRepository {
+	return &mongoAuthorRepository{collection: database.Collection(authorsCollection)}
+}
+
+func (r *mongoAuthorRepository) Create(ctx context.Context, author entity.Author) (primitive.ObjectID, error) {
+	document := author.Authors(primitive.NewObjectID())
+	document.NameKeys = authorNameKeys(author)
+	document.CreatedAt = time.Now().UTC()
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return document.ID, nil
+}
+
+func (r *mongoAuthorRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Authors, error) {
+	var author entity.Authors
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&author)
+	if err == mongo.ErrNoDocuments {
+		return author, ErrAuthorNotFound
+	}
+	return author, err
+}
+
+func (r *mongoAuthorRepository) List(ctx context.Context, query string, page, limit int64) (AuthorPage, error) {
+	result := AuthorPage{Authors: []entity.Authors{}}
+	filter := bson.M{}
+	if key := utils.AuthorNameKey(query); key != "" {
+		filter["nameKeys"] = bson.M{"$regex": regexp.QuoteMeta(key)}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Authors)
+	return result, err
+}
+
+func (r *mongoAuthorRepository) FindByName(ctx context.Context, name string) ([]entity.Authors, error) {
+	authors := []entity.Authors{}
+	key := utils.AuthorNameKey(name)
+	if key == "" {
+		return authors, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"nameKeys": key})
+	if err != nil {
+		return authors, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &authors)
+	return authors, err
+}
+
+func (r *mongoAuthorRepository) Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error) {
+	distinct := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		distinct[id] = true
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return count == int64(len(distinct)), err
+}
+
+func (r *mongoAuthorRepository) Update(ctx context.Context, id primitive.ObjectID, author entity.Author) error {
+	set := bson.M{
+		"name":        author.Name,
+		"variants":    author.Variants,
+		"biography":   author.Biography,
+		"identifiers": author.Identifiers,
+		"nameKeys":    authorNameKeys(author),
+		"updatedAt":   time.Now().UTC(),
+	}
+	unset := bson.M{}
+	if author.BirthYear != nil {
+		set["birthYear"] = *author.BirthYear
+	} else {
+		unset["birthYear"] = ""
+	}
+	if author.DeathYear != nil {
+		set["deathYear"] = *author.DeathYear
+	} else {
+		unset["deathYear"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrAuthorNotFound
+	}
+	return nil
+}
+
+func (r *mongoAuthorRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrAuthorNotFound
+	}
+	return nil
+}
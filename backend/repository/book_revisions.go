@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrRevisionNotFound is returned when a book has no revision with the requested number
+var ErrRevisionNotFound = errors.New("book revision not found")
+
+// RevisionPage is a single page of a book history, newest revision first
+type RevisionPage struct {
+	Revisions []entity.BookRevision
+	Total     int64
+}
+
+// RevisionRepository stores the history of book changes. Revisions are append only,
+// a book and revision number pair is unique.
+type RevisionRepository interface {
+	Create(ctx context.Context, revision entity.BookRevision) error
+	Get(ctx context.Context, bookID primitive.ObjectID, revision int64) (entity.BookRevision, error)
+	List(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (RevisionPage, error)
+	// DeleteByBook removes the whole history of a book
+	DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryRevisionRepository struct {
+	mu        sync.RWMutex
+	revisions map[primitive.ObjectID][]entity.BookRevision
+}
+
+// NewMemoryRevisionRepository returns a RevisionRepository that keeps the history in memory, useful for tests and local demos
+func NewMemoryRevisionRepository() RevisionRepository {
+	return &memoryRevisionRepository{revisions: map[primitive.ObjectID][]entity.BookRevision{}}
+}
+
+func (r *memoryRevisionRepository) Create(ctx context.Context, revision entity.BookRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revision.ID = primitive.NewObjectID()
+	r.revisions[revision.BookID] = append(r.revisions[revision.BookID], revision)
+	return nil
+}
+
+func (r *memoryRevisionRepository) Get(ctx context.Context, bookID primitive.ObjectID, revision int64) (entity.BookRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, item := range r.revisions[bookID] {
+		if item.Revision == revision {
+			return item, nil
+		}
+	}
+	return entity.BookRevision{}, ErrRevisionNotFound
+}
+
+func (r *memoryRevisionRepository) List(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (RevisionPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	history := r.revisions[bookID]
+	result := RevisionPage{Revisions: []entity.BookRevision{}, Total: int64(len(history))}
+
+	// revisions are appended in order, walk them backwards for newest first
+	start := int64(len(history)) - 1 - (page-1)*limit
+	for i := start; i >= 0 && i > start-limit; i-- {
+		result.Revisions = append(result.Revisions, history[i])
+	}
+	return result, nil
+}
+
+func (r *memoryRevisionRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.revisions, bookID)
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const bookRevisionsCollection = "book_revisions"
+
+type mongoRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRevisionRepository returns a RevisionRepository backed by the book_revisions collection of the given database
+func NewMongoRevisionRepository(database *mongo.Database) RevisionRepository {
+	return &mongoRevisionRepository{collection: database.Collection(bookRevisionsCollection)}
+}
+
+func (r *mongoRevisionRepository) Create(ctx context.Context, revision entity.BookRevision) error {
+	revision.ID = primitive.NilObjectID
+	_, err := r.collection.InsertOne(ctx, revision)
+	return err
+}
+
+func (r *mongoRevisionRepository) Get(ctx context.Context, bookID primitive.ObjectID, revision int64) (entity.BookRevision, error) {
+	var item entity.BookRevision
+	err := r.collection.FindOne(ctx, bson.M{"bookId": bookID, "revision": revision}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return item, ErrRevisionNotFound
+	}
+	return item, err
+}
+
+func (r *mongoRevisionRepository) List(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (RevisionPage, error) {
+	result := RevisionPage{Revisions: []entity.BookRevision{}}
+	filter := bson.M{"bookId": bookID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "revision", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Revisions)
+	return result, err
+}
+
+func (r *mongoRevisionRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"bookId": bookID})
+	return err
+}
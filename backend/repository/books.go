@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrBookNotFound is returned when no book matches the requested ID
+var ErrBookNotFound = errors.New("book not found")
+
+// ErrDuplicateISBN is returned when another book outside the trash is already stored with the same ISBN
+var ErrDuplicateISBN = errors.New("duplicate isbn")
+
+// ErrVersionConflict is returned when a book changed since the version the caller expected
+var ErrVersionConflict = errors.New("book version conflict")
+
+// ErrInvalidCursor is returned when the pagination cursor does not point to an existing book
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// ErrBookNotInTrash is returned when restoring a book that is not deleted
+var ErrBookNotInTrash = errors.New("book is not in the trash")
+
+// BookSortFields lists the fields a book list can be sorted by
+var BookSortFields = map[string]bool{
+	"title":     true,
+	"author":    true,
+	"year":      true,
+	"isbn":      true,
+	"genre":     true,
+	"createdAt": true,
+	"updatedAt": true,
+	"deletedAt": true,
+	"rating":    true,
+}
+
+// bookSortPaths maps the sort fields that are not stored under their own name to their document path
+var bookSortPaths = map[string]string{
+	"rating": "rating.average",
+}
+
+// BookFilter narrows a book list, zero values are ignored.
+// Deleted books are hidden unless Trashed is set, which lists only the deleted books.
+type BookFilter struct {
+	Trashed  bool
+	Author   string
+	AuthorID primitive.ObjectID
+	WorkID   primitive.ObjectID
+	// Genres matches the books in any of the given genre slugs
+	Genres   []string
+	ISBN     string
+	YearFrom int
+	YearTo   int
+	// CreatedAfter and UpdatedBefore are exclusive bounds, zero means unbounded
+	CreatedAfter  time.Time
+	UpdatedBefore time.Time
+}
+
+// SortField orders a book list by a single field
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ListOptions controls filtering, ordering and pagination of a book list.
+// When After is set the page starts right after that book and Page is ignored.
+// CollapseEditions lists one book per work, the first of its editions in the list order with the
+// number of matching editions, it cannot be combined with After.
+type ListOptions struct {
+	Filter           BookFilter
+	Sort             []SortField
+	Page             int64
+	Limit            int64
+	After            *primitive.ObjectID
+	CollapseEditions bool
+}
+
+// BookPage is a single page of a book list
+type BookPage struct {
+	Books      []entity.Books
+	Total      int64
+	NextCursor string
+}
+
+// SearchQuery is a full-text search over title, author and description.
+// Language selects the stemming rules, see services.LanguageEnglish and services.LanguageIndonesian.
+type SearchQuery struct {
+	Text     string
+	Language string
+	Page     int64
+	Limit    int64
+}
+
+// SearchResult is a matched book with its relevance score
+type SearchResult struct {
+	Book  entity.Books
+	Score float64
+}
+
+// SearchPage is a single page of search results ordered by relevance
+type SearchPage struct {
+	Results []SearchResult
+	Total   int64
+}
+
+// BookRepository abstracts the storage of books so controllers do not depend on a specific database.
+// Every write increments the book version, Update and Delete only apply when the stored version
+// still equals the given one and return ErrVersionConflict otherwise.
+type BookRepository interface {
+	Create(ctx context.Context, book entity.Book) (primitive.ObjectID, error)
+	ExistingISBNs(ctx context.Context, isbns []string) (map[string]bool, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Books, error)
+	GetByISBN(ctx context.Context, isbn string) (entity.Books, error)
+	List(ctx context.Context, opts ListOptions) (BookPage, error)
+	Search(ctx context.Context, query SearchQuery) (SearchPage, error)
+	Stream(ctx context.Context, filter BookFilter, sort []SortField, fn func(book entity.Books) error) error
+	Update(ctx context.Context, id primitive.ObjectID, book entity.Book, version int64) error
+	Delete(ctx context.Context, id primitive.ObjectID, version int64) error
+	Restore(ctx context.Context, id primitive.ObjectID) error
+	// ExpiredTrash lists the books deleted before the given time
+	ExpiredTrash(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error)
+	// Purge permanently removes a book deleted before the given time, it returns ErrBookNotInTrash
+	// when the book was restored or deleted later
+	Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error
+}
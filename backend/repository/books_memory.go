@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"library-books/entity"
+	"library-books/services"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryBookRepository struct {
+	mu    sync.RWMutex
+	books map[primitive.ObjectID]entity.Books
+	order []primitive.ObjectID
+}
+
+// NewMemoryBookRepository returns a BookRepository that keeps books in memory, useful for tests and local demos
+func NewMemoryBookRepository() BookRepository {
+	return &memoryBookRepository{books: map[primitive.ObjectID]entity.Books{}}
+}
+
+func (r *memoryBookRepository) Create(ctx context.Context, book entity.Book) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasISBN(book.ISBN, primitive.NilObjectID) {
+		return primitive.NilObjectID, ErrDuplicateISBN
+	}
+
+	id := primitive.NewObjectID()
+	stored := book.Books(id)
+	stored.Version = 1
+	r.books[id] = stored
+	r.order = append(r.order, id)
+	return id, nil
+}
+
+func (r *memoryBookRepository) ExistingISBNs(ctx context.Context, isbns []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	existing := map[string]bool{}
+	for _, isbn := range isbns {
+		if r.hasISBN(isbn, primitive.NilObjectID) {
+			existing[isbn] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *memoryBookRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Books, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt != nil {
+		return entity.Books{}, ErrBookNotFound
+	}
+	return book, nil
+}
+
+func (r *memoryBookRepository) GetByISBN(ctx context.Context, isbn string) (entity.Books, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range r.order {
+		if r.books[id].ISBN == isbn && r.books[id].DeletedAt == nil {
+			return r.books[id], nil
+		}
+	}
+	return entity.Books{}, ErrBookNotFound
+}
+
+func (r *memoryBookRepository) List(ctx context.Context, opts ListOptions) (BookPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	books := []entity.Books{}
+	for _, id := range r.order {
+		if matchBookFilter(r.books[id], opts.Filter) {
+			books = append(books, r.books[id])
+		}
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		return compareBooks(books[i], books[j], opts.Sort) < 0
+	})
+	if opts.CollapseEditions {
+		books = collapseEditions(books)
+	}
+
+	page := BookPage{Books: []entity.Books{}, Total: int64(len(books))}
+
+	start := int64(0)
+	if opts.After != nil {
+		after, ok := r.books[*opts.After]
+		if !ok {
+			return page, ErrInvalidCursor
+		}
+		start = int64(sort.Search(len(books), func(i int) bool {
+			return compareBooks(books[i], after, opts.Sort) > 0
+		}))
+	} else if opts.Page > 1 {
+		start = (opts.Page - 1) * opts.Limit
+	}
+
+	if start < int64(len(books)) {
+		end := start + opts.Limit
+		if end < int64(len(books)) {
+			if !opts.CollapseEditions {
+				page.NextCursor = books[end-1].ID.Hex()
+			}
+		} else {
+			end = int64(len(books))
+		}
+		page.Books = append(page.Books, books[start:end]...)
+	}
+	return page, nil
+}
+
+func (r *memoryBookRepository) Search(ctx context.Context, query SearchQuery) (SearchPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stems := services.QueryStems(query.Text, query.Language)
+	results := []SearchResult{}
+	for _, id := range r.order {
+		book := r.books[id]
+		if book.DeletedAt != nil {
+			continue
+		}
+		score := textScore(book.Title, stems, query.Language)*10 +
+			textScore(book.Author, stems, query.Language)*5 +
+			textScore(book.Description, stems, query.Language)
+		if score > 0 {
+			results = append(results, SearchResult{Book: book, Score: score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	page := SearchPage{Results: []SearchResult{}, Total: int64(len(results))}
+	start := (query.Page - 1) * query.Limit
+	if start < int64(len(results)) {
+		end := min(start+query.Limit, int64(len(results)))
+		page.Results = append(page.Results, results[start:end]...)
+	}
+	return page, nil
+}
+
+func (r *memoryBookRepository) Stream(ctx context.Context, filter BookFilter, fields []SortField, fn func(book entity.Books) error) error {
+	r.mu.RLock()
+	books := []entity.Books{}
+	for _, id := range r.order {
+		if matchBookFilter(r.books[id], filter) {
+			books = append(books, r.books[id])
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(books, func(i, j int) bool {
+		return compareBooks(books[i], books[j], fields) < 0
+	})
+	for _, book := range books {
+		if err := fn(book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryBookRepository) Update(ctx context.Context, id primitive.ObjectID, book entity.Book, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.books[id]
+	if !ok || existing.DeletedAt != nil {
+		return ErrBookNotFound
+	}
+	if existing.Version != version {
+		return ErrVersionConflict
+	}
+	if r.hasISBN(book.ISBN, id) {
+		return ErrDuplicateISBN
+	}
+
+	stored := book.Books(id)
+	stored.Version = existing.Version + 1
+	stored.Rating = existing.Rating
+	r.books[id] = stored
+	return nil
+}
+
+func (r *memoryBookRepository) Delete(ctx context.Context, id primitive.ObjectID, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt != nil {
+		return ErrBookNotFound
+	}
+	if book.Version != version {
+		return ErrVersionConflict
+	}
+	deletedAt := time.Now().UTC()
+	book.DeletedAt = &deletedAt
+	book.Version++
+	r.books[id] = book
+	return nil
+}
+
+func (r *memoryBookRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt == nil {
+		return ErrBookNotInTrash
+	}
+	if r.hasISBN(book.ISBN, id) {
+		return ErrDuplicateISBN
+	}
+	book.DeletedAt = nil
+	book.Version++
+	r.books[id] = book
+	return nil
+}
+
+func (r *memoryBookRepository) ExpiredTrash(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []primitive.ObjectID{}
+	for _, id := range r.order {
+		if deletedAt := r.books[id].DeletedAt; deletedAt != nil && !deletedAt.After(deletedBefore) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryBookRepository) Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt == nil || book.DeletedAt.After(deletedBefore) {
+		return ErrBookNotInTrash
+	}
+	delete(r.books, id)
+	for i, stored := range r.order {
+		if stored == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// hasISBN mirrors the unique ISBN index of the live books, books without an ISBN and books in the trash never conflict
+func (r *memoryBookRepository) hasISBN(isbn string, except primitive.ObjectID) bool {
+	if isbn == "" {
+		return false
+	}
+	for id, book := range r.books {
+		if id != except && book.DeletedAt == nil && book.ISBN == isbn {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseEditions keeps the first edition of each work of a sorted list with the number of editions,
+// books without a work stand for themselves
+func collapseEditions(books []entity.Books) []entity.Books {
+	collapsed := []entity.Books{}
+	index := map[primitive.ObjectID]int{}
+	for _, book := range books {
+		key := book.ID
+		if book.WorkID != nil {
+			key = *book.WorkID
+		}
+		if i, ok := index[key]; ok {
+			collapsed[i].EditionCount++
+			continue
+		}
+		index[key] = len(collapsed)
+		book.EditionCount = 1
+		collapsed = append(collapsed, book)
+	}
+	return collapsed
+}
+
+// matchBookFilter mirrors the MongoDB filter semantics of bookFilterQuery
+func matchBookFilter(book entity.Books, filter BookFilter) bool {
+	if filter.Trashed != (book.DeletedAt != nil) {
+		return false
+	}
+	if filter.Author != "" && !strings.Contains(strings.ToLower(book.Author), strings.ToLower(filter.Author)) {
+		return false
+	}
+	if !filter.AuthorID.IsZero() && !slices.Contains(book.AuthorIDs, filter.AuthorID) {
+		return false
+	}
+	if !filter.WorkID.IsZero() && (book.WorkID == nil || *book.WorkID != filter.WorkID) {
+		return false
+	}
+	if len(filter.Genres) > 0 && !slices.Contains(filter.Genres, book.Genre) {
+		return false
+	}
+	if filter.ISBN != "" && book.ISBN != filter.ISBN {
+		return false
+	}
+	if filter.YearFrom > 0 && book.Year < filter.YearFrom {
+		return false
+	}
+	if filter.YearTo > 0 && book.Year > filter.YearTo {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() && !book.CreatedAt.After(filter.CreatedAfter) {
+		return false
+	}
+	if !filter.UpdatedBefore.IsZero() && (book.UpdatedAt == nil || !book.UpdatedAt.Before(filter.UpdatedBefore)) {
+		return false
+	}
+	return true
+}
+
+// compareBooks orders two books by the given sort fields, falling back to the ID like sortDocument
+func compareBooks(a, b entity.Books, fields []SortField) int {
+	for _, field := range fields {
+		result := compareValues(bookField(a, field.Field), bookField(b, field.Field))
+		if field.Descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return strings.Compare(a.ID.Hex(), b.ID.Hex())
+}
+
+// bookField returns the value of a sortable field by its JSON name
+func bookField(book entity.Books, field string) interface{} {
+	switch field {
+	case "title":
+		return book.Title
+	case "author":
+		return book.Author
+	case "year":
+		return book.Year
+	case "isbn":
+		return book.ISBN
+	case "genre":
+		return book.Genre
+	case "createdAt":
+		return book.CreatedAt
+	case "updatedAt":
+		if book.UpdatedAt == nil {
+			return time.Time{}
+		}
+		return *book.UpdatedAt
+	case "deletedAt":
+		if book.DeletedAt == nil {
+			return time.Time{}
+		}
+		return *book.DeletedAt
+	case "rating":
+		if book.Rating == nil {
+			return 0.0
+		}
+		return book.Rating.Average
+	}
+	return nil
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int:
+		return cmp.Compare(a, b.(int))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
+
+// textScore counts the words of a text sharing a stem with the query, weighted like the MongoDB text index
+func textScore(text string, stems map[string]bool, lang string) float64 {
+	score := 0.0
+	for _, word := range services.Tokenize(text) {
+		if stems[services.Stem(word, lang)] {
+			score++
+		}
+	}
+	return score
+}
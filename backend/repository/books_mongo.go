@@ -0,0 +1,486 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"library-books/services"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const booksCollection = "books"
+
+type mongoBookRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoBookRepository returns a BookRepository backed by the books collection of the given database
+func NewMongoBookRepository(database *mongo.Database) BookRepository {
+	return &mongoBookRepository{collection: database.Collection(booksCollection)}
+}
+
+// textIndexed is a book as written to the books collection, with the language the text index stems it in
+type textIndexed[T any] struct {
+	Book         T      `bson:",inline"`
+	TextLanguage string `bson:"textLanguage"`
+}
+
+func (r *mongoBookRepository) Create(ctx context.Context, book entity.Book) (primitive.ObjectID, error) {
+	document := book.Books(primitive.NilObjectID)
+	document.Version = 1
+
+	result, err := r.collection.InsertOne(ctx, textIndexed[entity.Books]{document, services.TextIndexLanguage(book.Language)})
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrDuplicateISBN
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	id, _ := result.InsertedID.(primitive.ObjectID)
+	return id, nil
+}
+
+func (r *mongoBookRepository) ExistingISBNs(ctx context.Context, isbns []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	if len(isbns) == 0 {
+		return existing, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"isbn": bson.M{"$in": isbns}, "deletedAt": nil}, options.Find().SetProjection(bson.M{"isbn": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var book entity.Books
+		if err := cursor.Decode(&book); err != nil {
+			return nil, err
+		}
+		existing[book.ISBN] = true
+	}
+	return existing, cursor.Err()
+}
+
+func (r *mongoBookRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Books, error) {
+	var book entity.Books
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "deletedAt": nil}).Decode(&book)
+	if err == mongo.ErrNoDocuments {
+		return book, ErrBookNotFound
+	}
+	return book, err
+}
+
+func (r *mongoBookRepository) GetByISBN(ctx context.Context, isbn string) (entity.Books, error) {
+	var book entity.Books
+	err := r.collection.FindOne(ctx, bson.M{"isbn": isbn, "deletedAt": nil}).Decode(&book)
+	if err == mongo.ErrNoDocuments {
+		return book, ErrBookNotFound
+	}
+	return book, err
+}
+
+func (r *mongoBookRepository) List(ctx context.Context, opts ListOptions) (BookPage, error) {
+	if opts.CollapseEditions {
+		return r.listWorks(ctx, opts)
+	}
+	page := BookPage{Books: []entity.Books{}}
+
+	filter := bookFilterQuery(opts.Filter)
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return page, err
+	}
+	page.Total = total
+
+	// fetch one extra document to know whether a next page exists
+	findOptions := options.Find().SetSort(sortDocument(opts.Sort)).SetLimit(opts.Limit + 1)
+	query := filter
+	if opts.After != nil {
+		var after bson.M
+		err := r.collection.FindOne(ctx, bson.M{"_id": *opts.After}).Decode(&after)
+		if err == mongo.ErrNoDocuments {
+			return page, ErrInvalidCursor
+		}
+		if err != nil {
+			return page, err
+		}
+		query = bson.M{"$and": []bson.M{filter, keysetQuery(opts.Sort, after)}}
+	} else if opts.Page > 1 {
+		findOptions.SetSkip((opts.Page - 1) * opts.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return page, err
+	}
+	defer cursor.Close(ctx)
+
+	// Iterate over the cursor and decode each item into a new item variable
+	for cursor.Next(ctx) {
+		var itemBook entity.Books
+		if err := cursor.Decode(&itemBook); err != nil {
+			continue
+		}
+		page.Books = append(page.Books, itemBook)
+	}
+	if err := cursor.Err(); err != nil {
+		return page, err
+	}
+
+	if int64(len(page.Books)) > opts.Limit {
+		page.Books = page.Books[:opts.Limit]
+		page.NextCursor = page.Books[opts.Limit-1].ID.Hex()
+	}
+	return page, nil
+}
+
+// listWorks lists one book per work, books without a work stand for themselves
+func (r *mongoBookRepository) listWorks(ctx context.Context, opts ListOptions) (BookPage, error) {
+	page := BookPage{Books: []entity.Books{}}
+
+	sort := sortDocument(opts.Sort)
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bookFilterQuery(opts.Filter)}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          bson.M{"$ifNull": bson.A{"$workId", "$_id"}},
+			"book":         bson.M{"$first": "$$ROOT"},
+			"editionCount": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{"$mergeObjects": bson.A{"$book", bson.M{"editionCount": "$editionCount"}}}}}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"books": bson.A{bson.M{"$skip": (max(opts.Page, 1) - 1) * opts.Limit}, bson.M{"$limit": opts.Limit}},
+		}}},
+	})
+	if err != nil {
+		return page, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Books []struct {
+			entity.Books `bson:",inline"`
+			EditionCount int `bson:"editionCount"`
+		} `bson:"books"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return page, err
+	}
+	if len(result) == 0 {
+		return page, nil
+	}
+
+	if len(result[0].Total) > 0 {
+		page.Total = result[0].Total[0].Count
+	}
+	for _, item := range result[0].Books {
+		book := item.Books
+		book.EditionCount = item.EditionCount
+		page.Books = append(page.Books, book)
+	}
+	return page, nil
+}
+
+func (r *mongoBookRepository) Search(ctx context.Context, query SearchQuery) (SearchPage, error) {
+	page := SearchPage{Results: []SearchResult{}}
+
+	filter := bson.M{"$text": textSearch(query), "deletedAt": nil}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return page, err
+	}
+	page.Total = total
+
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip((query.Page - 1) * query.Limit).
+		SetLimit(query.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return page, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var item struct {
+			entity.Books `bson:",inline"`
+			Score        float64 `bson:"score"`
+		}
+		if err := cursor.Decode(&item); err != nil {
+			continue
+		}
+		page.Results = append(page.Results, SearchResult{Book: item.Books, Score: item.Score})
+	}
+	return page, cursor.Err()
+}
+
+// Stream iterates the matching books straight from the cursor, stopping at the first error returned by fn
+func (r *mongoBookRepository) Stream(ctx context.Context, filter BookFilter, sort []SortField, fn func(book entity.Books) error) error {
+	cursor, err := r.collection.Find(ctx, bookFilterQuery(filter), options.Find().SetSort(sortDocument(sort)))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var book entity.Books
+		if err := cursor.Decode(&book); err != nil {
+			return err
+		}
+		if err := fn(book); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *mongoBookRepository) Update(ctx context.Context, id primitive.ObjectID, book entity.Book, version int64) error {
+	update := bson.M{"$set": textIndexed[entity.Book]{book, services.TextIndexLanguage(book.Language)}, "$inc": bson.M{"version": 1}}
+	unset := bson.M{}
+	if book.OriginalISBN == "" {
+		unset["originalIsbn"] = ""
+	}
+	if len(book.AuthorIDs) == 0 {
+		unset["authorIds"] = ""
+	}
+	if book.WorkID == nil {
+		unset["workId"] = ""
+	}
+	if book.Language == "" {
+		unset["language"] = ""
+	}
+	if book.Edition == "" {
+		unset["edition"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "deletedAt": nil, "version": version}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateISBN
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return r.missedWrite(ctx, id)
+	}
+	return nil
+}
+
+// Delete moves the book to the trash, it stays stored until it is restored or purged
+func (r *mongoBookRepository) Delete(ctx context.Context, id primitive.ObjectID, version int64) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": nil, "version": version},
+		bson.M{"$set": bson.M{"deletedAt": time.Now().UTC()}, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return r.missedWrite(ctx, id)
+	}
+	return nil
+}
+
+// missedWrite explains why a compare-and-set write matched no book
+func (r *mongoBookRepository) missedWrite(ctx context.Context, id primitive.ObjectID) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id, "deletedAt": nil})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrBookNotFound
+	}
+	return ErrVersionConflict
+}
+
+// Restore moves the book out of the trash, it fails with ErrDuplicateISBN when a live book took its ISBN meanwhile
+func (r *mongoBookRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"deletedAt": ""}, "$inc": bson.M{"version": 1}},
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateISBN
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrBookNotInTrash
+	}
+	return nil
+}
+
+// ExpiredTrash lists the books deleted before the given time
+func (r *mongoBookRepository) ExpiredTrash(ctx context.Context, deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"deletedAt": bson.M{"$lte": deletedBefore}},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var row struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		ids = append(ids, row.ID)
+	}
+	return ids, cursor.Err()
+}
+
+// Purge permanently removes a book deleted before the given time
+func (r *mongoBookRepository) Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "deletedAt": bson.M{"$lte": deletedBefore}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrBookNotInTrash
+	}
+	return nil
+}
+
+// textSearch builds the $text operator. MongoDB has no Indonesian stemmer, so Indonesian books are
+// indexed without language rules (see services.TextIndexLanguage) and Indonesian queries are searched
+// without them too, expanded with the stems of their words.
+func textSearch(query SearchQuery) bson.M {
+	if query.Language != services.LanguageIndonesian {
+		return bson.M{"$search": query.Text, "$language": "english"}
+	}
+
+	terms := []string{query.Text}
+	for stem := range services.QueryStems(query.Text, query.Language) {
+		terms = append(terms, stem)
+	}
+	return bson.M{"$search": strings.Join(terms, " "), "$language": "none"}
+}
+
+// bookFilterQuery translates a BookFilter into a MongoDB query
+func bookFilterQuery(filter BookFilter) bson.M {
+	query := bson.M{"deletedAt": nil}
+	if filter.Trashed {
+		query["deletedAt"] = bson.M{"$ne": nil}
+	}
+	if filter.Author != "" {
+		query["author"] = bson.M{"$regex": regexp.QuoteMeta(filter.Author), "$options": "i"}
+	}
+	if !filter.AuthorID.IsZero() {
+		query["authorIds"] = filter.AuthorID
+	}
+	if !filter.WorkID.IsZero() {
+		query["workId"] = filter.WorkID
+	}
+	if len(filter.Genres) > 0 {
+		query["genre"] = bson.M{"$in": filter.Genres}
+	}
+	if filter.ISBN != "" {
+		query["isbn"] = filter.ISBN
+	}
+
+	year := bson.M{}
+	if filter.YearFrom > 0 {
+		year["$gte"] = filter.YearFrom
+	}
+	if filter.YearTo > 0 {
+		year["$lte"] = filter.YearTo
+	}
+	if len(year) > 0 {
+		query["year"] = year
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		query["createdAt"] = bson.M{"$gt": filter.CreatedAfter}
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		query["updatedAt"] = bson.M{"$lt": filter.UpdatedBefore}
+	}
+	return query
+}
+
+// sortDocument builds the sort stage, always ending with _id so the order is stable for cursors
+func sortDocument(sort []SortField) bson.D {
+	document := bson.D{}
+	for _, field := range sort {
+		direction := 1
+		if field.Descending {
+			direction = -1
+		}
+		document = append(document, bson.E{Key: sortPath(field.Field), Value: direction})
+	}
+	return append(document, bson.E{Key: "_id", Value: 1})
+}
+
+// keysetQuery matches the documents ordered after the given document for the given sort.
+// Missing values sort like null before every set value, so they follow every value in a descending sort.
+func keysetQuery(sort []SortField, after bson.M) bson.M {
+	fields := append(append([]SortField{}, sort...), SortField{Field: "_id"})
+
+	or := []bson.M{}
+	for i, field := range fields {
+		clause := bson.M{}
+		for _, previous := range fields[:i] {
+			clause[sortPath(previous.Field)] = documentValue(after, sortPath(previous.Field))
+		}
+
+		value := documentValue(after, sortPath(field.Field))
+		switch {
+		case value == nil && field.Descending:
+			continue
+		case value == nil:
+			clause[sortPath(field.Field)] = bson.M{"$ne": nil}
+		case field.Descending:
+			clause[sortPath(field.Field)] = bson.M{"$not": bson.M{"$gte": value}}
+		default:
+			clause[sortPath(field.Field)] = bson.M{"$gt": value}
+		}
+		or = append(or, clause)
+	}
+	return bson.M{"$or": or}
+}
+
+// sortPath returns the document path of a sort field
+func sortPath(field string) string {
+	if path, ok := bookSortPaths[field]; ok {
+		return path
+	}
+	return field
+}
+
+// documentValue returns the value at a dotted path of a decoded document, nil when it is missing
+func documentValue(document bson.M, path string) interface{} {
+	var value interface{} = document
+	for _, key := range strings.Split(path, ".") {
+		embedded, ok := value.(bson.M)
+		if !ok {
+			return nil
+		}
+		value = embedded[key]
+	}
+	return value
+}
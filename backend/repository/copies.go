@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrCopyNotFound is returned when the book has no copy with the requested ID
+var ErrCopyNotFound = errors.New("copy not found")
+
+// ErrDuplicateBarcode is returned when another copy is already stored with the same barcode
+var ErrDuplicateBarcode = errors.New("duplicate barcode")
+
+// ErrCopyStatusChanged is returned when updating a copy whose status changed since it was read
+var ErrCopyStatusChanged = errors.New("copy status changed")
+
+// ErrCopyOnLoan is returned when deleting a copy that is currently lent out or held for a patron
+var ErrCopyOnLoan = errors.New("copy is on loan")
+
+// CopyRepository stores the physical copies of books, every copy belongs to a single book
+// and the barcode is unique across the library.
+type CopyRepository interface {
+	Create(ctx context.Context, bookID primitive.ObjectID, copy entity.Copy) (primitive.ObjectID, error)
+	Get(ctx context.Context, bookID, id primitive.ObjectID) (entity.Copies, error)
+	Find(ctx context.Context, id primitive.ObjectID) (entity.Copies, error)
+	FindByBarcode(ctx context.Context, barcode string) (entity.Copies, error)
+	List(ctx context.Context, bookID primitive.ObjectID) ([]entity.Copies, error)
+	// Update replaces a copy that still has the status it was read with
+	Update(ctx context.Context, bookID, id primitive.ObjectID, status string, copy entity.Copy) error
+	Delete(ctx context.Context, bookID, id primitive.ObjectID) error
+	// DeleteByBook deletes the copies of a book that are not on loan or on hold
+	DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error
+	// Availability counts the copies of every given book by status, books without copies are left out
+	Availability(ctx context.Context, bookIDs []primitive.ObjectID) (map[primitive.ObjectID]entity.CopyAvailability, error)
+}
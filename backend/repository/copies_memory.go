@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryCopyRepository struct {
+	mu     sync.RWMutex
+	copies map[primitive.ObjectID]entity.Copies
+}
+
+// NewMemoryCopyRepository returns a CopyRepository that keeps copies in memory, useful for tests and local demos
+func NewMemoryCopyRepository() CopyRepository {
+	return &memoryCopyRepository{copies: map[primitive.ObjectID]entity.Copies{}}
+}
+
+func (r *memoryCopyRepository) Create(ctx context.Context, bookID primitive.ObjectID, copy entity.Copy) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasBarcode(copy.Barcode, primitive.NilObjectID) {
+		return primitive.NilObjectID, ErrDuplicateBarcode
+	}
+
+	stored := copy.Copies(primitive.NewObjectID(), bookID)
+	stored.CreatedAt = time.Now().UTC()
+	r.copies[stored.ID] = stored
+	return stored.ID, nil
+}
+
+func (r *memoryCopyRepository) Get(ctx context.Context, bookID, id primitive.ObjectID) (entity.Copies, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.copies[id]
+	if !ok || stored.BookID != bookID {
+		return entity.Copies{}, ErrCopyNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryCopyRepository) Find(ctx context.Context, id primitive.ObjectID) (entity.Copies, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.copies[id]
+	if !ok {
+		return entity.Copies{}, ErrCopyNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryCopyRepository) FindByBarcode(ctx context.Context, barcode string) (entity.Copies, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.copies {
+		if stored.Barcode == barcode {
+			return stored, nil
+		}
+	}
+	return entity.Copies{}, ErrCopyNotFound
+}
+
+func (r *memoryCopyRepository) List(ctx context.Context, bookID primitive.ObjectID) ([]entity.Copies, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	copies := []entity.Copies{}
+	for _, stored := range r.copies {
+		if stored.BookID == bookID {
+			copies = append(copies, stored)
+		}
+	}
+	sort.Slice(copies, func(i, j int) bool { return copies[i].Barcode < copies[j].Barcode })
+	return copies, nil
+}
+
+func (r *memoryCopyRepository) Update(ctx context.Context, bookID, id primitive.ObjectID, status string, copy entity.Copy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.copies[id]
+	if !ok || stored.BookID != bookID {
+		return ErrCopyNotFound
+	}
+	if stored.Status != status {
+		return ErrCopyStatusChanged
+	}
+	if r.hasBarcode(copy.Barcode, id) {
+		return ErrDuplicateBarcode
+	}
+
+	updated := copy.Copies(id, bookID)
+	updated.CreatedAt = stored.CreatedAt
+	updatedAt := time.Now().UTC()
+	updated.UpdatedAt = &updatedAt
+	r.copies[id] = updated
+	return nil
+}
+
+func (r *memoryCopyRepository) Delete(ctx context.Context, bookID, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.copies[id]
+	if !ok || stored.BookID != bookID {
+		return ErrCopyNotFound
+	}
+	if entity.InCirculation(stored.Status) {
+		return ErrCopyOnLoan
+	}
+	delete(r.copies, id)
+	return nil
+}
+
+func (r *memoryCopyRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, stored := range r.copies {
+		if stored.BookID == bookID && !entity.InCirculation(stored.Status) {
+			delete(r.copies, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryCopyRepository) Availability(ctx context.Context, bookIDs []primitive.ObjectID) (map[primitive.ObjectID]entity.CopyAvailability, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := map[primitive.ObjectID]bool{}
+	for _, id := range bookIDs {
+		wanted[id] = true
+	}
+
+	availability := map[primitive.ObjectID]entity.CopyAvailability{}
+	for _, stored := range r.copies {
+		if !wanted[stored.BookID] {
+			continue
+		}
+		counts := availability[stored.BookID]
+		counts.Add(stored.Status)
+		availability[stored.BookID] = counts
+	}
+	return availability, nil
+}
+
+// hasBarcode mirrors the unique barcode index, except ignores the copy being updated
+func (r *memoryCopyRepository) hasBarcode(barcode string, except primitive.ObjectID) bool {
+	for id, stored := range r.copies {
+		if id != except && stored.Barcode == barcode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const copiesCollection = "copies"
+
+type mongoCopyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCopyRepository returns a CopyRepository backed by the copies collection of the given database
+func NewMongoCopyRepository(database *mongo.Database) CopyRepository {
+	return &mongoCopyRepository{collection: database.Collection(copiesCollection)}
+}
+
+func (r *mongoCopyRepository) Create(ctx context.Context, bookID primitive.ObjectID, copy entity.Copy) (primitive.ObjectID, error) {
+	document := copy.Copies(primitive.NewObjectID(), bookID)
+	document.CreatedAt = time.Now().UTC()
+
+	_, err := r.collection.InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrDuplicateBarcode
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return document.ID, nil
+}
+
+func (r *mongoCopyRepository) Get(ctx context.Context, bookID, id primitive.ObjectID) (entity.Copies, error) {
+	return r.findOne(ctx, bson.M{"_id": id, "bookId": bookID})
+}
+
+func (r *mongoCopyRepository) Find(ctx context.Context, id primitive.ObjectID) (entity.Copies, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *mongoCopyRepository) FindByBarcode(ctx context.Context, barcode string) (entity.Copies, error) {
+	return r.findOne(ctx, bson.M{"barcode": barcode})
+}
+
+func (r *mongoCopyRepository) findOne(ctx context.Context, filter bson.M) (entity.Copies, error) {
+	var item entity.Copies
+	err := r.collection.FindOne(ctx, filter).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return item, ErrCopyNotFound
+	}
+	return item, err
+}
+
+func (r *mongoCopyRepository) List(ctx context.Context, bookID primitive.ObjectID) ([]entity.Copies, error) {
+	copies := []entity.Copies{}
+	cursor, err := r.collection.Find(ctx, bson.M{"bookId": bookID}, options.Find().SetSort(bson.D{{Key: "barcode", Value: 1}}))
+	if err != nil {
+		return copies, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &copies)
+	return copies, err
+}
+
+func (r *mongoCopyRepository) Update(ctx context.Context, bookID, id primitive.ObjectID, status string, copy entity.Copy) error {
+	update := bson.M{"$set": bson.M{
+		"barcode":   copy.Barcode,
+		"branch":    copy.Branch,
+		"shelf":     copy.Shelf,
+		"status":    copy.Status,
+		"price":     copy.Price,
+		"updatedAt": time.Now().UTC(),
+	}}
+	unset := bson.M{}
+	if copy.AcquiredAt != nil {
+		update["$set"].(bson.M)["acquiredAt"] = copy.AcquiredAt
+	} else {
+		unset["acquiredAt"] = ""
+	}
+	if copy.Material != "" {
+		update["$set"].(bson.M)["material"] = copy.Material
+	} else {
+		unset["material"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	// the status filter keeps a concurrent checkout or hold from being overwritten
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "bookId": bookID, "status": status}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateBarcode
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	// nothing matched, either the copy does not exist or its status changed
+	if _, err := r.Get(ctx, bookID, id); err != nil {
+		return err
+	}
+	return ErrCopyStatusChanged
+}
+
+func (r *mongoCopyRepository) Delete(ctx context.Context, bookID, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "bookId": bookID, "status": bson.M{"$nin": bson.A{entity.CopyOnLoan, entity.CopyOnHold}}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount > 0 {
+		return nil
+	}
+
+	// nothing deleted, either the copy does not exist or it is in circulation
+	if _, err := r.Get(ctx, bookID, id); err != nil {
+		return err
+	}
+	return ErrCopyOnLoan
+}
+
+func (r *mongoCopyRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"bookId": bookID, "status": bson.M{"$nin": bson.A{entity.CopyOnLoan, entity.CopyOnHold}}})
+	return err
+}
+
+func (r *mongoCopyRepository) Availability(ctx context.Context, bookIDs []primitive.ObjectID) (map[primitive.ObjectID]entity.CopyAvailability, error) {
+	availability := map[primitive.ObjectID]entity.CopyAvailability{}
+	if len(bookIDs) == 0 {
+		return availability, nil
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"bookId": bson.M{"$in": bookIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"bookId": "$bookId", "status": "$status"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return availability, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var group struct {
+			ID struct {
+				BookID primitive.ObjectID `bson:"bookId"`
+				Status string             `bson:"status"`
+			} `bson:"_id"`
+			Count int `bson:"count"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return availability, err
+		}
+
+		counts := availability[group.ID.BookID]
+		for i := 0; i < group.Count; i++ {
+			counts.Add(group.ID.Status)
+		}
+		availability[group.ID.BookID] = counts
+	}
+	return availability, cursor.Err()
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FineEntryPage is a single page of fine ledger entries, newest first
+type FineEntryPage struct {
+	Entries []entity.FineEntries
+	Total   int64
+}
+
+// FineRepository stores the waivers and adjustments librarians make to fine balances.
+// The fines themselves live on the loans, see LoanRepository.ListLate.
+type FineRepository interface {
+	Add(ctx context.Context, entry entity.FineEntries) (primitive.ObjectID, error)
+	List(ctx context.Context, userID string, page, limit int64) (FineEntryPage, error)
+	// Sum adds up the entries of a user
+	Sum(ctx context.Context, userID string) (float64, error)
+}
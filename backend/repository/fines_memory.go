@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryFineRepository struct {
+	mu      sync.RWMutex
+	entries []entity.FineEntries
+}
+
+// NewMemoryFineRepository returns a FineRepository that keeps the ledger in memory, useful for tests and local demos
+func NewMemoryFineRepository() FineRepository {
+	return &memoryFineRepository{}
+}
+
+func (r *memoryFineRepository) Add(ctx context.Context, entry entity.FineEntries) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = primitive.NewObjectID()
+	r.entries = append(r.entries, entry)
+	return entry.ID, nil
+}
+
+func (r *memoryFineRepository) List(ctx context.Context, userID string, page, limit int64) (FineEntryPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []entity.FineEntries{}
+	for _, entry := range r.entries {
+		if entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ID.Hex() > entries[j].ID.Hex()
+	})
+
+	result := FineEntryPage{Entries: []entity.FineEntries{}, Total: int64(len(entries))}
+	start := (page - 1) * limit
+	if start < int64(len(entries)) {
+		end := min(start+limit, int64(len(entries)))
+		result.Entries = entries[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryFineRepository) Sum(ctx context.Context, userID string) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total float64
+	for _, entry := range r.entries {
+		if entry.UserID == userID {
+			total += entry.Amount
+		}
+	}
+	return total, nil
+}
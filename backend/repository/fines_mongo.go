@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const finesCollection = "fines"
+
+type mongoFineRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoFineRepository returns a FineRepository backed by the fines collection of the given database
+func NewMongoFineRepository(database *mongo.Database) FineRepository {
+	return &mongoFineRepository{collection: database.Collection(finesCollection)}
+}
+
+func (r *mongoFineRepository) Add(ctx context.Context, entry entity.FineEntries) (primitive.ObjectID, error) {
+	entry.ID = primitive.NewObjectID()
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return entry.ID, nil
+}
+
+func (r *mongoFineRepository) List(ctx context.Context, userID string, page, limit int64) (FineEntryPage, error) {
+	result := FineEntryPage{Entries: []entity.FineEntries{}}
+	filter := bson.M{"userId": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Entries)
+	return result, err
+}
+
+func (r *mongoFineRepository) Sum(ctx context.Context, userID string) (float64, error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"userId": userID}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$amount"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []struct {
+		Total float64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil || len(totals) == 0 {
+		return 0, err
+	}
+	return totals[0].Total, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+)
+
+// ErrGenreNotFound is returned when no genre matches the requested slug
+var ErrGenreNotFound = errors.New("genre not found")
+
+// ErrDuplicateGenre is returned when a genre is already stored with the same slug
+var ErrDuplicateGenre = errors.New("duplicate genre")
+
+// GenreRepository stores the genre taxonomy, genres are identified by their slug
+type GenreRepository interface {
+	Create(ctx context.Context, genre entity.Genre) error
+	Get(ctx context.Context, slug string) (entity.Genres, error)
+	// List returns the whole taxonomy ordered by slug
+	List(ctx context.Context) ([]entity.Genres, error)
+	// Update replaces the parent and labels of a genre, the slug cannot change
+	Update(ctx context.Context, slug string, genre entity.Genre) error
+	Delete(ctx context.Context, slug string) error
+}
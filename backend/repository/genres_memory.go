@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryGenreRepository struct {
+	mu     sync.RWMutex
+	genres map[string]entity.Genres
+}
+
+// NewMemoryGenreRepository returns a GenreRepository that keeps the taxonomy in memory, useful for tests and local demos
+func NewMemoryGenreRepository() GenreRepository {
+	return &memoryGenreRepository{genres: map[string]entity.Genres{}}
+}
+
+func (r *memoryGenreRepository) Create(ctx context.Context, genre entity.Genre) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.genres[genre.Slug]; ok {
+		return ErrDuplicateGenre
+	}
+	stored := genre.Genres()
+	stored.CreatedAt = time.Now().UTC()
+	r.genres[genre.Slug] = stored
+	return nil
+}
+
+func (r *memoryGenreRepository) Get(ctx context.Context, slug string) (entity.Genres, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.genres[slug]
+	if !ok {
+		return entity.Genres{}, ErrGenreNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryGenreRepository) List(ctx context.Context) ([]entity.Genres, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	genres := make([]entity.Genres, 0, len(r.genres))
+	for _, stored := range r.genres {
+		genres = append(genres, stored)
+	}
+	sort.Slice(genres, func(i, j int) bool { return genres[i].Slug < genres[j].Slug })
+	return genres, nil
+}
+
+func (r *memoryGenreRepository) Update(ctx context.Context, slug string, genre entity.Genre) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.genres[slug]
+	if !ok {
+		return ErrGenreNotFound
+	}
+	stored.Parent = genre.Parent
+	stored.Labels = genre.Labels
+	updatedAt := time.Now().UTC()
+	stored.UpdatedAt = &updatedAt
+	r.genres[slug] = stored
+	return nil
+}
+
+func (r *memoryGenreRepository) Delete(ctx context.Context, slug string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.genres[slug]; !ok {
+		return ErrGenreNotFound
+	}
+	delete(r.genres, slug)
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const genresCollection = "genres"
+
+type mongoGenreRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoGenreRepository returns a GenreRepository backed by the genres collection of the given database
+func NewMongoGenreRepository(database *mongo.Database) GenreRepository {
+	return &mongoGenreRepository{collection: database.Collection(genresCollection)}
+}
+
+func (r *mongoGenreRepository) Create(ctx context.Context, genre entity.Genre) error {
+	document := genre.Genres()
+	document.CreatedAt = time.Now().UTC()
+
+	_, err := r.collection.InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateGenre
+	}
+	return err
+}
+
+func (r *mongoGenreRepository) Get(ctx context.Context, slug string) (entity.Genres, error) {
+	var genre entity.Genres
+	err := r.collection.FindOne(ctx, bson.M{"_id": slug}).Decode(&genre)
+	if err == mongo.ErrNoDocuments {
+		return genre, ErrGenreNotFound
+	}
+	return genre, err
+}
+
+func (r *mongoGenreRepository) List(ctx context.Context) ([]entity.Genres, error) {
+	genres := []entity.Genres{}
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return genres, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &genres)
+	return genres, err
+}
+
+func (r *mongoGenreRepository) Update(ctx context.Context, slug string, genre entity.Genre) error {
+	set := bson.M{"labels": genre.Labels, "updatedAt": time.Now().UTC()}
+	update := bson.M{"$set": set}
+	if genre.Parent != "" {
+		set["parent"] = genre.Parent
+	} else {
+		update["$unset"] = bson.M{"parent": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": slug}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrGenreNotFound
+	}
+	return nil
+}
+
+func (r *mongoGenreRepository) Delete(ctx context.Context, slug string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": slug})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrGenreNotFound
+	}
+	return nil
+}
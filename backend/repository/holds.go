@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrHoldNotFound is returned when no hold matches the requested ID
+var ErrHoldNotFound = errors.New("hold not found")
+
+// ErrDuplicateHold is returned when the user already has a waiting or ready hold on the book
+var ErrDuplicateHold = errors.New("duplicate hold")
+
+// ErrHoldNotActive is returned when cancelling a hold that was already fulfilled, expired or cancelled
+var ErrHoldNotActive = errors.New("hold is not active")
+
+// HoldPage is a single page of holds, most recently placed first
+type HoldPage struct {
+	Holds []entity.Holds
+	Total int64
+}
+
+// HoldRepository stores the hold queues. Waiting holds on a book are served first in first out by placedAt,
+// a copy leaving a ready hold through Cancel or Expire is passed to the next waiting hold in the same atomic write.
+type HoldRepository interface {
+	Place(ctx context.Context, hold entity.Holds) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Holds, error)
+	// Position is the 1-based place of a waiting hold in the queue of its book
+	Position(ctx context.Context, hold entity.Holds) (int64, error)
+	CountWaiting(ctx context.Context, bookID primitive.ObjectID) (int64, error)
+	// ListByUser lists the holds of a user, an empty status lists holds in every status
+	ListByUser(ctx context.Context, userID string, status string, page, limit int64) (HoldPage, error)
+	Cancel(ctx context.Context, id primitive.ObjectID, closedAt time.Time, pickupBy time.Time) error
+	// Allocate sets an available copy aside for the next waiting hold on its book, if any
+	Allocate(ctx context.Context, copyID primitive.ObjectID, at time.Time, pickupBy time.Time) error
+	// Expire closes the ready holds not picked up before now and passes their copies on, it returns the number expired
+	Expire(ctx context.Context, now time.Time, pickupBy time.Time) (int64, error)
+	// DeleteByBook removes every hold on a book, in any status
+	DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryHoldRepository struct {
+	mu     sync.RWMutex
+	holds  map[primitive.ObjectID]entity.Holds
+	copies *memoryCopyRepository
+}
+
+// NewMemoryHoldRepository returns a HoldRepository that keeps holds in memory, useful for tests and local demos.
+// copies must come from NewMemoryCopyRepository, allocations update its copies.
+func NewMemoryHoldRepository(copies CopyRepository) HoldRepository {
+	return &memoryHoldRepository{
+		holds:  map[primitive.ObjectID]entity.Holds{},
+		copies: copies.(*memoryCopyRepository),
+	}
+}
+
+func (r *memoryHoldRepository) Place(ctx context.Context, hold entity.Holds) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.openHold(hold.UserID, hold.BookID); ok {
+		return primitive.NilObjectID, ErrDuplicateHold
+	}
+
+	hold.ID = primitive.NewObjectID()
+	hold.Status = entity.HoldWaiting
+	r.holds[hold.ID] = hold
+	return hold.ID, nil
+}
+
+func (r *memoryHoldRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Holds, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hold, ok := r.holds[id]
+	if !ok {
+		return entity.Holds{}, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+func (r *memoryHoldRepository) Position(ctx context.Context, hold entity.Holds) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	position := int64(1)
+	for _, other := range r.holds {
+		if other.BookID == hold.BookID && other.Status == entity.HoldWaiting && queuedBefore(other, hold) {
+			position++
+		}
+	}
+	return position, nil
+}
+
+func (r *memoryHoldRepository) CountWaiting(ctx context.Context, bookID primitive.ObjectID) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, hold := range r.holds {
+		if hold.BookID == bookID && hold.Status == entity.HoldWaiting {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryHoldRepository) ListByUser(ctx context.Context, userID string, status string, page, limit int64) (HoldPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holds := []entity.Holds{}
+	for _, hold := range r.holds {
+		if hold.UserID == userID && (status == "" || hold.Status == status) {
+			holds = append(holds, hold)
+		}
+	}
+	sort.Slice(holds, func(i, j int) bool { return queuedBefore(holds[j], holds[i]) })
+
+	result := HoldPage{Holds: []entity.Holds{}, Total: int64(len(holds))}
+	start := (page - 1) * limit
+	if start < int64(len(holds)) {
+		end := min(start+limit, int64(len(holds)))
+		result.Holds = holds[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryHoldRepository) Cancel(ctx context.Context, id primitive.ObjectID, closedAt time.Time, pickupBy time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.copies.mu.Lock()
+	defer r.copies.mu.Unlock()
+
+	hold, ok := r.holds[id]
+	if !ok {
+		return ErrHoldNotFound
+	}
+	if hold.Status != entity.HoldWaiting && hold.Status != entity.HoldReady {
+		return ErrHoldNotActive
+	}
+	r.close(hold, entity.HoldCancelled, closedAt)
+
+	// the copy set aside for a cancelled ready hold goes to the next patron
+	if hold.Status == entity.HoldReady && hold.CopyID != nil {
+		r.allocateCopy(*hold.CopyID, entity.CopyOnHold, closedAt, pickupBy)
+	}
+	return nil
+}
+
+func (r *memoryHoldRepository) Allocate(ctx context.Context, copyID primitive.ObjectID, at time.Time, pickupBy time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.copies.mu.Lock()
+	defer r.copies.mu.Unlock()
+
+	r.allocateCopy(copyID, entity.CopyAvailable, at, pickupBy)
+	return nil
+}
+
+func (r *memoryHoldRepository) Expire(ctx context.Context, now time.Time, pickupBy time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.copies.mu.Lock()
+	defer r.copies.mu.Unlock()
+
+	var expired int64
+	for _, hold := range r.holds {
+		if hold.Status != entity.HoldReady || hold.PickupBy == nil || !hold.PickupBy.Before(now) {
+			continue
+		}
+		r.close(hold, entity.HoldExpired, now)
+		expired++
+		if hold.CopyID != nil {
+			r.allocateCopy(*hold.CopyID, entity.CopyOnHold, now, pickupBy)
+		}
+	}
+	return expired, nil
+}
+
+func (r *memoryHoldRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, hold := range r.holds {
+		if hold.BookID == bookID {
+			delete(r.holds, id)
+		}
+	}
+	return nil
+}
+
+// openHold finds the waiting or ready hold of a user on a book, the caller must hold the lock
+func (r *memoryHoldRepository) openHold(userID string, bookID primitive.ObjectID) (entity.Holds, bool) {
+	for _, hold := range r.holds {
+		if hold.UserID == userID && hold.BookID == bookID && (hold.Status == entity.HoldWaiting || hold.Status == entity.HoldReady) {
+			return hold, true
+		}
+	}
+	return entity.Holds{}, false
+}
+
+// close moves a hold to a final status, the caller must hold the lock
+func (r *memoryHoldRepository) close(hold entity.Holds, status string, closedAt time.Time) {
+	hold.Status = status
+	hold.ClosedAt = &closedAt
+	r.holds[hold.ID] = hold
+}
+
+// allocateCopy mirrors the Mongo allocateCopy, the caller must hold the locks of the holds and the copies
+func (r *memoryHoldRepository) allocateCopy(copyID primitive.ObjectID, from string, at time.Time, pickupBy time.Time) {
+	copy, ok := r.copies.copies[copyID]
+	if !ok || copy.Status != from {
+		return
+	}
+
+	var next *entity.Holds
+	for _, hold := range r.holds {
+		if hold.BookID == copy.BookID && hold.Status == entity.HoldWaiting && (next == nil || queuedBefore(hold, *next)) {
+			hold := hold
+			next = &hold
+		}
+	}
+
+	status := entity.CopyAvailable
+	if next != nil {
+		next.Status = entity.HoldReady
+		next.CopyID = &copy.ID
+		next.ReadyAt = &at
+		next.PickupBy = &pickupBy
+		r.holds[next.ID] = *next
+		status = entity.CopyOnHold
+	}
+	if status != from {
+		copy.Status = status
+		copy.UpdatedAt = &at
+		r.copies.copies[copy.ID] = copy
+	}
+}
+
+// queuedBefore orders holds by placedAt, then by ID like the Mongo queue
+func queuedBefore(a, b entity.Holds) bool {
+	if !a.PlacedAt.Equal(b.PlacedAt) {
+		return a.PlacedAt.Before(b.PlacedAt)
+	}
+	return a.ID.Hex() < b.ID.Hex()
+}
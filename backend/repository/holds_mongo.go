@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const holdsCollection = "holds"
+
+// openHold matches the holds still in the queue or waiting for pickup
+var openHold = bson.M{"$in": bson.A{entity.HoldWaiting, entity.HoldReady}}
+
+type mongoHoldRepository struct {
+	holds  *mongo.Collection
+	copies *mongo.Collection
+}
+
+// NewMongoHoldRepository returns a HoldRepository backed by the holds and copies collections of the given database.
+// Cancel, Allocate and Expire use multi-document transactions, so MongoDB must run as a replica set.
+func NewMongoHoldRepository(database *mongo.Database) HoldRepository {
+	return &mongoHoldRepository{
+		holds:  database.Collection(holdsCollection),
+		copies: database.Collection(copiesCollection),
+	}
+}
+
+func (r *mongoHoldRepository) Place(ctx context.Context, hold entity.Holds) (primitive.ObjectID, error) {
+	hold.ID = primitive.NewObjectID()
+	hold.Status = entity.HoldWaiting
+
+	// insert only when the user has no open hold on the book
+	result, err := r.holds.UpdateOne(ctx,
+		bson.M{"userId": hold.UserID, "bookId": hold.BookID, "status": openHold},
+		bson.M{"$setOnInsert": hold},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if result.UpsertedCount == 0 {
+		return primitive.NilObjectID, ErrDuplicateHold
+	}
+	return hold.ID, nil
+}
+
+func (r *mongoHoldRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Holds, error) {
+	var hold entity.Holds
+	err := r.holds.FindOne(ctx, bson.M{"_id": id}).Decode(&hold)
+	if err == mongo.ErrNoDocuments {
+		return hold, ErrHoldNotFound
+	}
+	return hold, err
+}
+
+func (r *mongoHoldRepository) Position(ctx context.Context, hold entity.Holds) (int64, error) {
+	ahead, err := r.holds.CountDocuments(ctx, bson.M{
+		"bookId": hold.BookID,
+		"status": entity.HoldWaiting,
+		"$or": bson.A{
+			bson.M{"placedAt": bson.M{"$lt": hold.PlacedAt}},
+			bson.M{"placedAt": hold.PlacedAt, "_id": bson.M{"$lt": hold.ID}},
+		},
+	})
+	return ahead + 1, err
+}
+
+func (r *mongoHoldRepository) CountWaiting(ctx context.Context, bookID primitive.ObjectID) (int64, error) {
+	return r.holds.CountDocuments(ctx, bson.M{"bookId": bookID, "status": entity.HoldWaiting})
+}
+
+func (r *mongoHoldRepository) ListByUser(ctx context.Context, userID string, status string, page, limit int64) (HoldPage, error) {
+	result := HoldPage{Holds: []entity.Holds{}}
+	filter := bson.M{"userId": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.holds.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "placedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.holds.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Holds)
+	return result, err
+}
+
+func (r *mongoHoldRepository) Cancel(ctx context.Context, id primitive.ObjectID, closedAt time.Time, pickupBy time.Time) error {
+	return transaction(ctx, r.holds.Database(), func(sc mongo.SessionContext) error {
+		var hold entity.Holds
+		err := r.holds.FindOneAndUpdate(sc,
+			bson.M{"_id": id, "status": openHold},
+			bson.M{"$set": bson.M{"status": entity.HoldCancelled, "closedAt": closedAt}},
+		).Decode(&hold)
+		if err == mongo.ErrNoDocuments {
+			if _, err := r.Get(sc, id); err != nil {
+				return err
+			}
+			return ErrHoldNotActive
+		}
+		if err != nil {
+			return err
+		}
+
+		// the copy set aside for a cancelled ready hold goes to the next patron
+		if hold.Status == entity.HoldReady && hold.CopyID != nil {
+			return allocateCopy(sc, r.holds, r.copies, *hold.CopyID, hold.BookID, entity.CopyOnHold, closedAt, pickupBy)
+		}
+		return nil
+	})
+}
+
+func (r *mongoHoldRepository) Allocate(ctx context.Context, copyID primitive.ObjectID, at time.Time, pickupBy time.Time) error {
+	err := transaction(ctx, r.holds.Database(), func(sc mongo.SessionContext) error {
+		var copy entity.Copies
+		err := r.copies.FindOne(sc, bson.M{"_id": copyID, "status": entity.CopyAvailable}).Decode(&copy)
+		if err == mongo.ErrNoDocuments {
+			return ErrCopyNotAvailable
+		}
+		if err != nil {
+			return err
+		}
+		return allocateCopy(sc, r.holds, r.copies, copy.ID, copy.BookID, entity.CopyAvailable, at, pickupBy)
+	})
+	// a copy that is no longer on the shelf has nothing to allocate
+	if err == ErrCopyNotAvailable {
+		return nil
+	}
+	return err
+}
+
+func (r *mongoHoldRepository) Expire(ctx context.Context, now time.Time, pickupBy time.Time) (int64, error) {
+	cursor, err := r.holds.Find(ctx, bson.M{"status": entity.HoldReady, "pickupBy": bson.M{"$lt": now}})
+	if err != nil {
+		return 0, err
+	}
+	var overdue []entity.Holds
+	if err := cursor.All(ctx, &overdue); err != nil {
+		return 0, err
+	}
+
+	var expired int64
+	for _, hold := range overdue {
+		var closed bool
+		err := transaction(ctx, r.holds.Database(), func(sc mongo.SessionContext) error {
+			// the hold may have been picked up or cancelled since it was read
+			result, err := r.holds.UpdateOne(sc,
+				bson.M{"_id": hold.ID, "status": entity.HoldReady},
+				bson.M{"$set": bson.M{"status": entity.HoldExpired, "closedAt": now}},
+			)
+			if err != nil {
+				return err
+			}
+			closed = result.ModifiedCount > 0
+			if !closed || hold.CopyID == nil {
+				return nil
+			}
+			return allocateCopy(sc, r.holds, r.copies, *hold.CopyID, hold.BookID, entity.CopyOnHold, now, pickupBy)
+		})
+		if err != nil {
+			return expired, err
+		}
+		if closed {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *mongoHoldRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.holds.DeleteMany(ctx, bson.M{"bookId": bookID})
+	return err
+}
+
+// allocateCopy moves a copy out of the given status, either to the oldest waiting hold on its book
+// or back on the shelf when nobody is waiting. It must run inside a transaction.
+func allocateCopy(sc mongo.SessionContext, holds, copies *mongo.Collection, copyID, bookID primitive.ObjectID, from string, at time.Time, pickupBy time.Time) error {
+	status := entity.CopyAvailable
+	err := holds.FindOneAndUpdate(sc,
+		bson.M{"bookId": bookID, "status": entity.HoldWaiting},
+		bson.M{"$set": bson.M{"status": entity.HoldReady, "copyId": copyID, "readyAt": at, "pickupBy": pickupBy}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "placedAt", Value: 1}, {Key: "_id", Value: 1}}),
+	).Err()
+	if err == nil {
+		status = entity.CopyOnHold
+	} else if err != mongo.ErrNoDocuments {
+		return err
+	}
+	if status == from {
+		return nil
+	}
+
+	result, err := copies.UpdateOne(sc,
+		bson.M{"_id": copyID, "status": from},
+		bson.M{"$set": bson.M{"status": status, "updatedAt": at}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrCopyNotAvailable
+	}
+	return nil
+}
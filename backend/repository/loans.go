@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrLoanNotFound is returned when no loan matches the requested ID
+var ErrLoanNotFound = errors.New("loan not found")
+
+// ErrCopyNotAvailable is returned when checking out a copy that is not available on the shelf
+var ErrCopyNotAvailable = errors.New("copy is not available")
+
+// ErrLoanNotActive is returned when returning or renewing a loan that was already returned
+var ErrLoanNotActive = errors.New("loan is not active")
+
+// ErrRenewalLimit is returned when a loan was already renewed the maximum number of times
+var ErrRenewalLimit = errors.New("loan renewal limit reached")
+
+// LoanPage is a single page of loans, most recent checkout first
+type LoanPage struct {
+	Loans []entity.Loans
+	Total int64
+}
+
+// LoanRepository stores loans. Checkout and Return change the loan, the status of its copy and the hold queue
+// in a single atomic write, a copy can only be on one active loan at a time.
+type LoanRepository interface {
+	// Checkout lends an available copy, or the copy set aside for the borrower, and fulfils their open hold on the book.
+	// A different copy set aside for the borrower is passed to the next hold with the given pickup deadline.
+	Checkout(ctx context.Context, loan entity.Loans, pickupBy time.Time) (primitive.ObjectID, error)
+	// Return closes the loan charging the given fine and sets the copy aside for the next waiting hold until pickupBy,
+	// or puts it back on the shelf
+	Return(ctx context.Context, id primitive.ObjectID, returnedAt time.Time, pickupBy time.Time, fine float64) error
+	Renew(ctx context.Context, id primitive.ObjectID, dueAt time.Time, maxRenewals int) error
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Loans, error)
+	CountActive(ctx context.Context, userID string) (int64, error)
+	// DeleteReturnedByBook deletes the returned loans of a book that charged no fine,
+	// fined loans stay as they make up the fine balance of their borrower
+	DeleteReturnedByBook(ctx context.Context, bookID primitive.ObjectID) error
+	// ListLate lists the loans of a user that were returned with a fine or are still out past their due date at now
+	ListLate(ctx context.Context, userID string, now time.Time) ([]entity.Loans, error)
+	// ListByUser lists the loans of a user, an empty status lists loans in every status
+	ListByUser(ctx context.Context, userID string, status string, page, limit int64) (LoanPage, error)
+	// Stream calls fn with every loan, active or returned, stopping at the first error
+	Stream(ctx context.Context, fn func(loan entity.Loans) error) error
+}
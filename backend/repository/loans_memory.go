@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryLoanRepository struct {
+	mu     sync.RWMutex
+	loans  map[primitive.ObjectID]entity.Loans
+	copies *memoryCopyRepository
+	holds  *memoryHoldRepository
+}
+
+// NewMemoryLoanRepository returns a LoanRepository that keeps loans in memory, useful for tests and local demos.
+// copies must come from NewMemoryCopyRepository and holds from NewMemoryHoldRepository over the same copies,
+// checkouts and returns update both. Locks are taken in the order loans, holds, copies.
+func NewMemoryLoanRepository(copies CopyRepository, holds HoldRepository) LoanRepository {
+	return &memoryLoanRepository{
+		loans:  map[primitive.ObjectID]entity.Loans{},
+		copies: copies.(*memoryCopyRepository),
+		holds:  holds.(*memoryHoldRepository),
+	}
+}
+
+func (r *memoryLoanRepository) Checkout(ctx context.Context, loan entity.Loans, pickupBy time.Time) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.holds.mu.Lock()
+	defer r.holds.mu.Unlock()
+	r.copies.mu.Lock()
+	defer r.copies.mu.Unlock()
+
+	copy, ok := r.copies.copies[loan.CopyID]
+	hold, held := r.holds.openHold(loan.UserID, loan.BookID)
+	setAside := held && hold.Status == entity.HoldReady && hold.CopyID != nil && *hold.CopyID == loan.CopyID
+	if !ok || (copy.Status != entity.CopyAvailable && !(setAside && copy.Status == entity.CopyOnHold)) {
+		return primitive.NilObjectID, ErrCopyNotAvailable
+	}
+
+	// checking the book out fulfils the open hold of the borrower on it
+	if held {
+		r.holds.close(hold, entity.HoldFulfilled, loan.CheckedOutAt)
+		if hold.Status == entity.HoldReady && hold.CopyID != nil && !setAside {
+			r.holds.allocateCopy(*hold.CopyID, entity.CopyOnHold, loan.CheckedOutAt, pickupBy)
+		}
+	}
+	copy.Status = entity.CopyOnLoan
+	copy.UpdatedAt = &loan.CheckedOutAt
+	r.copies.copies[copy.ID] = copy
+
+	loan.ID = primitive.NewObjectID()
+	loan.Status = entity.LoanActive
+	r.loans[loan.ID] = loan
+	return loan.ID, nil
+}
+
+func (r *memoryLoanRepository) Return(ctx context.Context, id primitive.ObjectID, returnedAt time.Time, pickupBy time.Time, fine float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.holds.mu.Lock()
+	defer r.holds.mu.Unlock()
+	r.copies.mu.Lock()
+	defer r.copies.mu.Unlock()
+
+	loan, ok := r.loans[id]
+	if !ok {
+		return ErrLoanNotFound
+	}
+	if loan.Status != entity.LoanActive {
+		return ErrLoanNotActive
+	}
+	loan.Status = entity.LoanReturned
+	loan.ReturnedAt = &returnedAt
+	loan.Fine = fine
+	r.loans[id] = loan
+
+	r.holds.allocateCopy(loan.CopyID, entity.CopyOnLoan, returnedAt, pickupBy)
+	return nil
+}
+
+func (r *memoryLoanRepository) Renew(ctx context.Context, id primitive.ObjectID, dueAt time.Time, maxRenewals int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loan, ok := r.loans[id]
+	if !ok {
+		return ErrLoanNotFound
+	}
+	if loan.Status != entity.LoanActive {
+		return ErrLoanNotActive
+	}
+	if loan.Renewals >= maxRenewals {
+		return ErrRenewalLimit
+	}
+	loan.DueAt = dueAt
+	loan.Renewals++
+	r.loans[id] = loan
+	return nil
+}
+
+func (r *memoryLoanRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Loans, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loan, ok := r.loans[id]
+	if !ok {
+		return entity.Loans{}, ErrLoanNotFound
+	}
+	return loan, nil
+}
+
+func (r *memoryLoanRepository) CountActive(ctx context.Context, userID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, loan := range r.loans {
+		if loan.UserID == userID && loan.Status == entity.LoanActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryLoanRepository) DeleteReturnedByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, loan := range r.loans {
+		if loan.BookID == bookID && loan.Status == entity.LoanReturned && loan.Fine <= 0 {
+			delete(r.loans, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryLoanRepository) ListLate(ctx context.Context, userID string, now time.Time) ([]entity.Loans, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loans := []entity.Loans{}
+	for _, loan := range r.loans {
+		if loan.UserID != userID {
+			continue
+		}
+		if (loan.Status == entity.LoanReturned && loan.Fine > 0) || (loan.Status == entity.LoanActive && loan.DueAt.Before(now)) {
+			loans = append(loans, loan)
+		}
+	}
+	sort.Slice(loans, func(i, j int) bool {
+		if !loans[i].DueAt.Equal(loans[j].DueAt) {
+			return loans[i].DueAt.Before(loans[j].DueAt)
+		}
+		return loans[i].ID.Hex() < loans[j].ID.Hex()
+	})
+	return loans, nil
+}
+
+func (r *memoryLoanRepository) ListByUser(ctx context.Context, userID string, status string, page, limit int64) (LoanPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loans := []entity.Loans{}
+	for _, loan := range r.loans {
+		if loan.UserID == userID && (status == "" || loan.Status == status) {
+			loans = append(loans, loan)
+		}
+	}
+	sort.Slice(loans, func(i, j int) bool {
+		if !loans[i].CheckedOutAt.Equal(loans[j].CheckedOutAt) {
+			return loans[i].CheckedOutAt.After(loans[j].CheckedOutAt)
+		}
+		return loans[i].ID.Hex() > loans[j].ID.Hex()
+	})
+
+	result := LoanPage{Loans: []entity.Loans{}, Total: int64(len(loans))}
+	start := (page - 1) * limit
+	if start < int64(len(loans)) {
+		end := min(start+limit, int64(len(loans)))
+		result.Loans = loans[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryLoanRepository) Stream(ctx context.Context, fn func(loan entity.Loans) error) error {
+	r.mu.RLock()
+	loans := make([]entity.Loans, 0, len(r.loans))
+	for _, loan := range r.loans {
+		loans = append(loans, loan)
+	}
+	r.mu.RUnlock()
+
+	for _, loan := range loans {
+		if err := fn(loan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const loansCollection = "loans"
+
+type mongoLoanRepository struct {
+	loans  *mongo.Collection
+	copies *mongo.Collection
+	holds  *mongo.Collection
+}
+
+// NewMongoLoanRepository returns a LoanRepository backed by the loans, copies and holds collections of the given database.
+// Checkout and Return use multi-document transactions, so MongoDB must run as a replica set.
+func NewMongoLoanRepository(database *mongo.Database) LoanRepository {
+	return &mongoLoanRepository{
+		loans:  database.Collection(loansCollection),
+		copies: database.Collection(copiesCollection),
+		holds:  database.Collection(holdsCollection),
+	}
+}
+
+func (r *mongoLoanRepository) Checkout(ctx context.Context, loan entity.Loans, pickupBy time.Time) (primitive.ObjectID, error) {
+	loan.ID = primitive.NewObjectID()
+	loan.Status = entity.LoanActive
+
+	err := transaction(ctx, r.loans.Database(), func(sc mongo.SessionContext) error {
+		copyStatus := entity.CopyAvailable
+
+		// checking the book out fulfils the open hold of the borrower on it
+		var hold entity.Holds
+		err := r.holds.FindOneAndUpdate(sc,
+			bson.M{"userId": loan.UserID, "bookId": loan.BookID, "status": openHold},
+			bson.M{"$set": bson.M{"status": entity.HoldFulfilled, "closedAt": loan.CheckedOutAt}},
+		).Decode(&hold)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if err == nil && hold.Status == entity.HoldReady && hold.CopyID != nil {
+			if *hold.CopyID == loan.CopyID {
+				copyStatus = entity.CopyOnHold
+			} else if err := allocateCopy(sc, r.holds, r.copies, *hold.CopyID, hold.BookID, entity.CopyOnHold, loan.CheckedOutAt, pickupBy); err != nil {
+				// the copy set aside for the borrower is no longer needed
+				return err
+			}
+		}
+
+		result, err := r.copies.UpdateOne(sc,
+			bson.M{"_id": loan.CopyID, "status": copyStatus},
+			bson.M{"$set": bson.M{"status": entity.CopyOnLoan, "updatedAt": loan.CheckedOutAt}},
+		)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return ErrCopyNotAvailable
+		}
+
+		_, err = r.loans.InsertOne(sc, loan)
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrCopyNotAvailable
+		}
+		return err
+	})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return loan.ID, nil
+}
+
+func (r *mongoLoanRepository) Return(ctx context.Context, id primitive.ObjectID, returnedAt time.Time, pickupBy time.Time, fine float64) error {
+	return transaction(ctx, r.loans.Database(), func(sc mongo.SessionContext) error {
+		var loan entity.Loans
+		err := r.loans.FindOneAndUpdate(sc,
+			bson.M{"_id": id, "status": entity.LoanActive},
+			bson.M{"$set": bson.M{"status": entity.LoanReturned, "returnedAt": returnedAt, "fine": fine}},
+		).Decode(&loan)
+		if err == mongo.ErrNoDocuments {
+			return r.missedLoan(sc, id)
+		}
+		if err != nil {
+			return err
+		}
+
+		return allocateCopy(sc, r.holds, r.copies, loan.CopyID, loan.BookID, entity.CopyOnLoan, returnedAt, pickupBy)
+	})
+}
+
+func (r *mongoLoanRepository) Renew(ctx context.Context, id primitive.ObjectID, dueAt time.Time, maxRenewals int) error {
+	result, err := r.loans.UpdateOne(ctx,
+		bson.M{"_id": id, "status": entity.LoanActive, "renewals": bson.M{"$lt": maxRenewals}},
+		bson.M{"$set": bson.M{"dueAt": dueAt}, "$inc": bson.M{"renewals": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	if err := r.missedLoan(ctx, id); err != nil {
+		return err
+	}
+	return ErrRenewalLimit
+}
+
+func (r *mongoLoanRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Loans, error) {
+	var loan entity.Loans
+	err := r.loans.FindOne(ctx, bson.M{"_id": id}).Decode(&loan)
+	if err == mongo.ErrNoDocuments {
+		return loan, ErrLoanNotFound
+	}
+	return loan, err
+}
+
+func (r *mongoLoanRepository) CountActive(ctx context.Context, userID string) (int64, error) {
+	return r.loans.CountDocuments(ctx, bson.M{"userId": userID, "status": entity.LoanActive})
+}
+
+func (r *mongoLoanRepository) DeleteReturnedByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.loans.DeleteMany(ctx, bson.M{"bookId": bookID, "status": entity.LoanReturned, "fine": bson.M{"$not": bson.M{"$gt": 0}}})
+	return err
+}
+
+func (r *mongoLoanRepository) ListLate(ctx context.Context, userID string, now time.Time) ([]entity.Loans, error) {
+	loans := []entity.Loans{}
+	cursor, err := r.loans.Find(ctx, bson.M{
+		"userId": userID,
+		"$or": bson.A{
+			bson.M{"status": entity.LoanReturned, "fine": bson.M{"$gt": 0}},
+			bson.M{"status": entity.LoanActive, "dueAt": bson.M{"$lt": now}},
+		},
+	}, options.Find().SetSort(bson.D{{Key: "dueAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return loans, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &loans)
+	return loans, err
+}
+
+func (r *mongoLoanRepository) ListByUser(ctx context.Context, userID string, status string, page, limit int64) (LoanPage, error) {
+	result := LoanPage{Loans: []entity.Loans{}}
+	filter := bson.M{"userId": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.loans.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "checkedOutAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.loans.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Loans)
+	return result, err
+}
+
+func (r *mongoLoanRepository) Stream(ctx context.Context, fn func(loan entity.Loans) error) error {
+	cursor, err := r.loans.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var loan entity.Loans
+		if err := cursor.Decode(&loan); err != nil {
+			return err
+		}
+		if err := fn(loan); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// missedLoan explains a conditional loan write that matched nothing, it returns nil when the loan is active
+func (r *mongoLoanRepository) missedLoan(ctx context.Context, id primitive.ObjectID) error {
+	loan, err := r.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if loan.Status != entity.LoanActive {
+		return ErrLoanNotActive
+	}
+	return nil
+}
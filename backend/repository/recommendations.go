@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecommendationRepository stores the scores computed by the recommendations job. Each refresh replaces
+// the whole previous result, books and users it left out have no recommendations anymore.
+type RecommendationRepository interface {
+	ReplaceSimilar(ctx context.Context, similar map[primitive.ObjectID][]entity.ScoredBook, computedAt time.Time) error
+	// Similar returns the books similar to a book, with no books until a refresh scored it
+	Similar(ctx context.Context, bookID primitive.ObjectID) (entity.Recommendations, error)
+	ReplaceForUsers(ctx context.Context, recommendations map[string][]entity.ScoredBook, computedAt time.Time) error
+	// ForUser returns the books recommended to a user, with no books until a refresh scored them
+	ForUser(ctx context.Context, userID string) (entity.Recommendations, error)
+	// RemoveBook drops the scores of a book and takes it out of the books recommended elsewhere
+	RemoveBook(ctx context.Context, bookID primitive.ObjectID) error
+}
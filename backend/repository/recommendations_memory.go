@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryRecommendationRepository struct {
+	mu      sync.RWMutex
+	similar map[primitive.ObjectID]entity.Recommendations
+	users   map[string]entity.Recommendations
+}
+
+// NewMemoryRecommendationRepository returns a RecommendationRepository that keeps scores in memory, useful for tests and local demos
+func NewMemoryRecommendationRepository() RecommendationRepository {
+	return &memoryRecommendationRepository{
+		similar: map[primitive.ObjectID]entity.Recommendations{},
+		users:   map[string]entity.Recommendations{},
+	}
+}
+
+func (r *memoryRecommendationRepository) ReplaceSimilar(ctx context.Context, similar map[primitive.ObjectID][]entity.ScoredBook, computedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.similar = replaceRecommendations(similar, computedAt)
+	return nil
+}
+
+func (r *memoryRecommendationRepository) Similar(ctx context.Context, bookID primitive.ObjectID) (entity.Recommendations, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return storedRecommendations(r.similar, bookID), nil
+}
+
+func (r *memoryRecommendationRepository) ReplaceForUsers(ctx context.Context, recommendations map[string][]entity.ScoredBook, computedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users = replaceRecommendations(recommendations, computedAt)
+	return nil
+}
+
+func (r *memoryRecommendationRepository) ForUser(ctx context.Context, userID string) (entity.Recommendations, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return storedRecommendations(r.users, userID), nil
+}
+
+func (r *memoryRecommendationRepository) RemoveBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.similar, bookID)
+	removeScoredBook(r.similar, bookID)
+	removeScoredBook(r.users, bookID)
+	return nil
+}
+
+func replaceRecommendations[K comparable](scored map[K][]entity.ScoredBook, computedAt time.Time) map[K]entity.Recommendations {
+	stored := make(map[K]entity.Recommendations, len(scored))
+	for key, books := range scored {
+		stored[key] = entity.Recommendations{Books: append([]entity.ScoredBook{}, books...), ComputedAt: computedAt}
+	}
+	return stored
+}
+
+func storedRecommendations[K comparable](stored map[K]entity.Recommendations, key K) entity.Recommendations {
+	recommendations, ok := stored[key]
+	if !ok {
+		return entity.Recommendations{Books: []entity.ScoredBook{}}
+	}
+	recommendations.Books = append([]entity.ScoredBook{}, recommendations.Books...)
+	return recommendations
+}
+
+// removeScoredBook takes a book out of every stored list
+func removeScoredBook[K comparable](stored map[K]entity.Recommendations, bookID primitive.ObjectID) {
+	for key, recommendations := range stored {
+		books := []entity.ScoredBook{}
+		for _, book := range recommendations.Books {
+			if book.BookID != bookID {
+				books = append(books, book)
+			}
+		}
+		recommendations.Books = books
+		stored[key] = recommendations
+	}
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	bookSimilaritiesCollection    = "book_similarities"
+	userRecommendationsCollection = "user_recommendations"
+)
+
+// recommendationBatch is the number of documents written by a single bulk write of a refresh
+const recommendationBatch = 1000
+
+type mongoRecommendationRepository struct {
+	similar *mongo.Collection
+	users   *mongo.Collection
+}
+
+// NewMongoRecommendationRepository returns a RecommendationRepository backed by the book_similarities and
+// user_recommendations collections of the given database, keyed by book ID and user ID. A refresh upserts
+// the new scores before deleting the ones it did not rewrite, readers see the previous scores meanwhile.
+func NewMongoRecommendationRepository(database *mongo.Database) RecommendationRepository {
+	return &mongoRecommendationRepository{
+		similar: database.Collection(bookSimilaritiesCollection),
+		users:   database.Collection(userRecommendationsCollection),
+	}
+}
+
+func (r *mongoRecommendationRepository) ReplaceSimilar(ctx context.Context, similar map[primitive.ObjectID][]entity.ScoredBook, computedAt time.Time) error {
+	return replaceScores(ctx, r.similar, similar, computedAt)
+}
+
+func (r *mongoRecommendationRepository) Similar(ctx context.Context, bookID primitive.ObjectID) (entity.Recommendations, error) {
+	return findScores(ctx, r.similar, bookID)
+}
+
+func (r *mongoRecommendationRepository) ReplaceForUsers(ctx context.Context, recommendations map[string][]entity.ScoredBook, computedAt time.Time) error {
+	return replaceScores(ctx, r.users, recommendations, computedAt)
+}
+
+func (r *mongoRecommendationRepository) ForUser(ctx context.Context, userID string) (entity.Recommendations, error) {
+	return findScores(ctx, r.users, userID)
+}
+
+func (r *mongoRecommendationRepository) RemoveBook(ctx context.Context, bookID primitive.ObjectID) error {
+	if _, err := r.similar.DeleteOne(ctx, bson.M{"_id": bookID}); err != nil {
+		return err
+	}
+	for _, collection := range []*mongo.Collection{r.similar, r.users} {
+		_, err := collection.UpdateMany(ctx,
+			bson.M{"books.bookId": bookID},
+			bson.M{"$pull": bson.M{"books": bson.M{"bookId": bookID}}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceScores upserts the scores of every key in batches, then deletes the documents of earlier refreshes
+func replaceScores[K comparable](ctx context.Context, collection *mongo.Collection, scored map[K][]entity.ScoredBook, computedAt time.Time) error {
+	// the dates are stored with millisecond precision, the cleanup compares against the stored value
+	computedAt = computedAt.Truncate(time.Millisecond)
+
+	models := make([]mongo.WriteModel, 0, min(len(scored), recommendationBatch))
+	for key, books := range scored {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": key}).
+			SetReplacement(entity.Recommendations{Books: books, ComputedAt: computedAt}).
+			SetUpsert(true))
+		if len(models) == recommendationBatch {
+			if _, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+				return err
+			}
+			models = models[:0]
+		}
+	}
+	if len(models) > 0 {
+		if _, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := collection.DeleteMany(ctx, bson.M{"computedAt": bson.M{"$lt": computedAt}})
+	return err
+}
+
+func findScores(ctx context.Context, collection *mongo.Collection, key interface{}) (entity.Recommendations, error) {
+	recommendations := entity.Recommendations{Books: []entity.ScoredBook{}}
+	err := collection.FindOne(ctx, bson.M{"_id": key}).Decode(&recommendations)
+	if err == mongo.ErrNoDocuments {
+		return entity.Recommendations{Books: []entity.ScoredBook{}}, nil
+	}
+	return recommendations, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrReviewNotFound is returned when no review matches the requested ID
+var ErrReviewNotFound = errors.New("review not found")
+
+// ErrDuplicateReview is returned when the user already reviewed the book
+var ErrDuplicateReview = errors.New("duplicate review")
+
+// ReviewPage is a single page of reviews, most recently written first
+type ReviewPage struct {
+	Reviews []entity.Reviews
+	Total   int64
+}
+
+// ReviewRepository stores the reviews of the books. Every write updates the rating summary of the
+// reviewed book in the same transaction, so the average, count and histogram always match the reviews.
+type ReviewRepository interface {
+	Create(ctx context.Context, review entity.Reviews) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Reviews, error)
+	ListByBook(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (ReviewPage, error)
+	Update(ctx context.Context, id primitive.ObjectID, review entity.Review) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// DeleteByBook removes the reviews of a book without touching its rating, for books being purged
+	DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error
+}
+
+// ratingDelta is the change of a rating summary when a rating is replaced, zero stands for no rating
+type ratingDelta struct {
+	Count     int64
+	Sum       int64
+	Histogram [5]int64
+}
+
+func newRatingDelta(removed, added int) ratingDelta {
+	delta := ratingDelta{}
+	if removed > 0 {
+		delta.Count--
+		delta.Sum -= int64(removed)
+		delta.Histogram[removed-1]--
+	}
+	if added > 0 {
+		delta.Count++
+		delta.Sum += int64(added)
+		delta.Histogram[added-1]++
+	}
+	return delta
+}
+
+// apply returns the summary with the delta applied, the average is rounded half to even to two decimals like $round
+func (d ratingDelta) apply(summary entity.RatingSummary) entity.RatingSummary {
+	summary.Count += d.Count
+	summary.Sum += d.Sum
+	for i := range summary.Histogram {
+		summary.Histogram[i] += d.Histogram[i]
+	}
+	summary.Average = 0
+	if summary.Count > 0 {
+		summary.Average = math.RoundToEven(float64(summary.Sum)/float64(summary.Count)*100) / 100
+	}
+	return summary
+}
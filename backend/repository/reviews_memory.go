@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryReviewRepository struct {
+	mu      sync.RWMutex
+	reviews map[primitive.ObjectID]entity.Reviews
+	books   *memoryBookRepository
+}
+
+// NewMemoryReviewRepository returns a ReviewRepository that keeps reviews in memory, useful for tests and local demos.
+// books must come from NewMemoryBookRepository, review writes update the rating of its books.
+// Locks are taken in the order reviews, books.
+func NewMemoryReviewRepository(books BookRepository) ReviewRepository {
+	return &memoryReviewRepository{
+		reviews: map[primitive.ObjectID]entity.Reviews{},
+		books:   books.(*memoryBookRepository),
+	}
+}
+
+func (r *memoryReviewRepository) Create(ctx context.Context, review entity.Reviews) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.reviews {
+		if stored.BookID == review.BookID && stored.UserID == review.UserID {
+			return primitive.NilObjectID, ErrDuplicateReview
+		}
+	}
+
+	review.ID = primitive.NewObjectID()
+	r.reviews[review.ID] = review
+	r.rate(review.BookID, newRatingDelta(0, review.Rating))
+	return review.ID, nil
+}
+
+func (r *memoryReviewRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Reviews, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	review, ok := r.reviews[id]
+	if !ok {
+		return entity.Reviews{}, ErrReviewNotFound
+	}
+	return review, nil
+}
+
+func (r *memoryReviewRepository) ListByBook(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (ReviewPage, error) {
+	return r.list(func(review entity.Reviews) bool { return review.BookID == bookID }, page, limit), nil
+}
+
+func (r *memoryReviewRepository) list(match func(review entity.Reviews) bool, page, limit int64) ReviewPage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := []entity.Reviews{}
+	for _, review := range r.reviews {
+		if match(review) {
+			reviews = append(reviews, review)
+		}
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+		}
+		return reviews[i].ID.Hex() > reviews[j].ID.Hex()
+	})
+
+	result := ReviewPage{Reviews: []entity.Reviews{}, Total: int64(len(reviews))}
+	start := (page - 1) * limit
+	if start < int64(len(reviews)) {
+		end := min(start+limit, int64(len(reviews)))
+		result.Reviews = reviews[start:end]
+	}
+	return result
+}
+
+func (r *memoryReviewRepository) Update(ctx context.Context, id primitive.ObjectID, review entity.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.reviews[id]
+	if !ok {
+		return ErrReviewNotFound
+	}
+	delta := newRatingDelta(stored.Rating, review.Rating)
+
+	stored.Rating = review.Rating
+	stored.Text = review.Text
+	updatedAt := time.Now().UTC()
+	stored.UpdatedAt = &updatedAt
+	r.reviews[id] = stored
+	r.rate(stored.BookID, delta)
+	return nil
+}
+
+func (r *memoryReviewRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.reviews[id]
+	if !ok {
+		return ErrReviewNotFound
+	}
+	delete(r.reviews, id)
+	r.rate(stored.BookID, newRatingDelta(stored.Rating, 0))
+	return nil
+}
+
+func (r *memoryReviewRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, review := range r.reviews {
+		if review.BookID == bookID {
+			delete(r.reviews, id)
+		}
+	}
+	return nil
+}
+
+// rate applies a delta to the rating summary of a book, the caller holds the review lock
+func (r *memoryReviewRepository) rate(bookID primitive.ObjectID, delta ratingDelta) {
+	r.books.mu.Lock()
+	defer r.books.mu.Unlock()
+
+	book, ok := r.books.books[bookID]
+	if !ok {
+		return
+	}
+	summary := entity.RatingSummary{}
+	if book.Rating != nil {
+		summary = *book.Rating
+	}
+	summary = delta.apply(summary)
+	book.Rating = &summary
+	r.books.books[bookID] = book
+}
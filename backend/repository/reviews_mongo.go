@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const reviewsCollection = "reviews"
+
+type mongoReviewRepository struct {
+	reviews *mongo.Collection
+	books   *mongo.Collection
+}
+
+// NewMongoReviewRepository returns a ReviewRepository backed by the reviews collection of the given database.
+// Reviews are written in transactions with the rating of their book, MongoDB must run as a replica set.
+func NewMongoReviewRepository(database *mongo.Database) ReviewRepository {
+	return &mongoReviewRepository{
+		reviews: database.Collection(reviewsCollection),
+		books:   database.Collection(booksCollection),
+	}
+}
+
+func (r *mongoReviewRepository) Create(ctx context.Context, review entity.Reviews) (primitive.ObjectID, error) {
+	review.ID = primitive.NewObjectID()
+
+	err := transaction(ctx, r.reviews.Database(), func(sc mongo.SessionContext) error {
+		_, err := r.reviews.InsertOne(sc, review)
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicateReview
+		}
+		if err != nil {
+			return err
+		}
+		return r.rate(sc, review.BookID, newRatingDelta(0, review.Rating))
+	})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return review.ID, nil
+}
+
+func (r *mongoReviewRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Reviews, error) {
+	var review entity.Reviews
+	err := r.reviews.FindOne(ctx, bson.M{"_id": id}).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return review, ErrReviewNotFound
+	}
+	return review, err
+}
+
+func (r *mongoReviewRepository) ListByBook(ctx context.Context, bookID primitive.ObjectID, page, limit int64) (ReviewPage, error) {
+	return r.list(ctx, bson.M{"bookId": bookID}, page, limit)
+}
+
+func (r *mongoReviewRepository) list(ctx context.Context, filter bson.M, page, limit int64) (ReviewPage, error) {
+	result := ReviewPage{Reviews: []entity.Reviews{}}
+
+	total, err := r.reviews.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.reviews.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Reviews)
+	return result, err
+}
+
+func (r *mongoReviewRepository) Update(ctx context.Context, id primitive.ObjectID, review entity.Review) error {
+	return transaction(ctx, r.reviews.Database(), func(sc mongo.SessionContext) error {
+		var previous entity.Reviews
+		err := r.reviews.FindOneAndUpdate(sc, bson.M{"_id": id}, bson.M{"$set": bson.M{
+			"rating":    review.Rating,
+			"text":      review.Text,
+			"updatedAt": time.Now().UTC(),
+		}}).Decode(&previous)
+		if err == mongo.ErrNoDocuments {
+			return ErrReviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if previous.Rating == review.Rating {
+			return nil
+		}
+		return r.rate(sc, previous.BookID, newRatingDelta(previous.Rating, review.Rating))
+	})
+}
+
+func (r *mongoReviewRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return transaction(ctx, r.reviews.Database(), func(sc mongo.SessionContext) error {
+		var previous entity.Reviews
+		err := r.reviews.FindOneAndDelete(sc, bson.M{"_id": id}).Decode(&previous)
+		if err == mongo.ErrNoDocuments {
+			return ErrReviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return r.rate(sc, previous.BookID, newRatingDelta(previous.Rating, 0))
+	})
+}
+
+func (r *mongoReviewRepository) DeleteByBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.reviews.DeleteMany(ctx, bson.M{"bookId": bookID})
+	return err
+}
+
+// rate applies a delta to the rating summary of a book in a single update, the average is
+// computed from the updated sum and count so concurrent writes cannot leave it stale
+func (r *mongoReviewRepository) rate(ctx context.Context, bookID primitive.ObjectID, delta ratingDelta) error {
+	histogram := bson.A{}
+	for _, count := range delta.Histogram {
+		histogram = append(histogram, count)
+	}
+
+	_, err := r.books.UpdateOne(ctx, bson.M{"_id": bookID}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rating.count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating.count", 0}}, delta.Count}},
+			"rating.sum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating.sum", 0}}, delta.Sum}},
+			"rating.histogram": bson.M{"$map": bson.M{
+				"input": bson.M{"$range": bson.A{0, len(delta.Histogram)}},
+				"as":    "star",
+				"in": bson.M{"$add": bson.A{
+					bson.M{"$arrayElemAt": bson.A{bson.M{"$ifNull": bson.A{"$rating.histogram", bson.A{0, 0, 0, 0, 0}}}, "$$star"}},
+					bson.M{"$arrayElemAt": bson.A{histogram, "$$star"}},
+				}},
+			}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating.average": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$rating.count", 0}},
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$rating.sum", "$rating.count"}}, 2}},
+				0,
+			}},
+		}}},
+	})
+	return err
+}
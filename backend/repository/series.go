@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrSeriesNotFound is returned when no series matches the requested ID
+var ErrSeriesNotFound = errors.New("series not found")
+
+// SeriesPage is a single page of series ordered by title
+type SeriesPage struct {
+	Series []entity.SeriesRecord
+	Total  int64
+}
+
+// SeriesRepository stores series, the works of a series hold their own position in it
+type SeriesRepository interface {
+	Create(ctx context.Context, series entity.Series) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.SeriesRecord, error)
+	// List pages through the series whose title contains the query ignoring case, an empty query lists every series
+	List(ctx context.Context, query string, page, limit int64) (SeriesPage, error)
+	// Exists reports whether every given series is stored
+	Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error)
+	Update(ctx context.Context, id primitive.ObjectID, series entity.Series) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
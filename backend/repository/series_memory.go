@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memorySeriesRepository struct {
+	mu     sync.RWMutex
+	series map[primitive.ObjectID]entity.SeriesRecord
+}
+
+// NewMemorySeriesRepository returns a SeriesRepository that keeps series in memory, useful for tests and local demos
+func NewMemorySeriesRepository() SeriesRepository {
+	return &memorySeriesRepository{series: map[primitive.ObjectID]entity.SeriesRecord{}}
+}
+
+func (r *memorySeriesRepository) Create(ctx context.Context, series entity.Series) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := entity.SeriesRecord{
+		ID:          primitive.NewObjectID(),
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   time.Now().UTC(),
+	}
+	r.series[stored.ID] = stored
+	return stored.ID, nil
+}
+
+func (r *memorySeriesRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.SeriesRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.series[id]
+	if !ok {
+		return entity.SeriesRecord{}, ErrSeriesNotFound
+	}
+	return stored, nil
+}
+
+func (r *memorySeriesRepository) List(ctx context.Context, query string, page, limit int64) (SeriesPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series := []entity.SeriesRecord{}
+	for _, stored := range r.series {
+		if strings.Contains(strings.ToLower(stored.Title), strings.ToLower(query)) {
+			series = append(series, stored)
+		}
+	}
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Title != series[j].Title {
+			return series[i].Title < series[j].Title
+		}
+		return series[i].ID.Hex() < series[j].ID.Hex()
+	})
+
+	result := SeriesPage{Series: []entity.SeriesRecord{}, Total: int64(len(series))}
+	start := (page - 1) * limit
+	if start < int64(len(series)) {
+		end := min(start+limit, int64(len(series)))
+		result.Series = series[start:end]
+	}
+	return result, nil
+}
+
+func (r *memorySeriesRepository) Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := r.series[id]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *memorySeriesRepository) Update(ctx context.Context, id primitive.ObjectID, series entity.Series) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.series[id]
+	if !ok {
+		return ErrSeriesNotFound
+	}
+	stored.Title = series.Title
+	stored.Description = series.Description
+	updatedAt := time.Now().UTC()
+	stored.UpdatedAt = &updatedAt
+	r.series[id] = stored
+	return nil
+}
+
+func (r *memorySeriesRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.series[id]; !ok {
+		return ErrSeriesNotFound
+	}
+	delete(r.series, id)
+	return nil
+}
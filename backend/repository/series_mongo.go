@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const seriesCollection = "series"
+
+type mongoSeriesRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSeriesRepository returns a SeriesRepository backed by the series collection of the given database
+func NewMongoSeriesRepository(database *mongo.Database) SeriesRepository {
+	return &mongoSeriesRepository{collection: database.Collection(seriesCollection)}
+}
+
+func (r *mongoSeriesRepository) Create(ctx context.Context, series entity.Series) (primitive.ObjectID, error) {
+	document := entity.SeriesRecord{
+		ID:          primitive.NewObjectID(),
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return document.ID, nil
+}
+
+func (r *mongoSeriesRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.SeriesRecord, error) {
+	var series entity.SeriesRecord
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&series)
+	if err == mongo.ErrNoDocuments {
+		return series, ErrSeriesNotFound
+	}
+	return series, err
+}
+
+func (r *mongoSeriesRepository) List(ctx context.Context, query string, page, limit int64) (SeriesPage, error) {
+	result := SeriesPage{Series: []entity.SeriesRecord{}}
+	filter := bson.M{}
+	if query != "" {
+		filter["title"] = bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Series)
+	return result, err
+}
+
+func (r *mongoSeriesRepository) Exists(ctx context.Context, ids []primitive.ObjectID) (bool, error) {
+	distinct := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		distinct[id] = true
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return count == int64(len(distinct)), err
+}
+
+func (r *mongoSeriesRepository) Update(ctx context.Context, id primitive.ObjectID, series entity.Series) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"title":       series.Title,
+		"description": series.Description,
+		"updatedAt":   time.Now().UTC(),
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *mongoSeriesRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrShelfNotFound is returned when no shelf matches the requested ID
+var ErrShelfNotFound = errors.New("shelf not found")
+
+// ErrDuplicateShelf is returned when the user already has a shelf with the same name, ignoring case
+var ErrDuplicateShelf = errors.New("duplicate shelf")
+
+// ErrShelfItemNotFound is returned when the book is not on the shelf
+var ErrShelfItemNotFound = errors.New("shelf item not found")
+
+// ErrDuplicateShelfItem is returned when the book is already on the shelf
+var ErrDuplicateShelfItem = errors.New("duplicate shelf item")
+
+// ShelfItemPage is a single page of the books of a shelf, most recently added first
+type ShelfItemPage struct {
+	Items []entity.ShelfItems
+	Total int64
+}
+
+// Shelving is a book on a shelf of a user
+type Shelving struct {
+	UserID  string
+	ShelfID primitive.ObjectID
+	BookID  primitive.ObjectID
+}
+
+// ShelfRepository stores the shelves of the users and the books on them. Deleting a shelf removes its books.
+type ShelfRepository interface {
+	// EnsureDefaults creates the default shelves the user does not have yet
+	EnsureDefaults(ctx context.Context, userID string) error
+	Create(ctx context.Context, userID string, shelf entity.Shelf) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Shelves, error)
+	// ListByUser lists the shelves of a user with their book count, default shelves first and custom
+	// shelves by name. publicOnly leaves the private shelves out.
+	ListByUser(ctx context.Context, userID string, publicOnly bool) ([]entity.Shelves, error)
+	Update(ctx context.Context, id primitive.ObjectID, shelf entity.Shelf) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	AddItem(ctx context.Context, item entity.ShelfItems) (primitive.ObjectID, error)
+	GetItem(ctx context.Context, shelfID, bookID primitive.ObjectID) (entity.ShelfItems, error)
+	ListItems(ctx context.Context, shelfID primitive.ObjectID, page, limit int64) (ShelfItemPage, error)
+	// UpdateItem replaces the reading dates and notes of a book on a shelf
+	UpdateItem(ctx context.Context, shelfID, bookID primitive.ObjectID, item entity.ShelfItems) error
+	RemoveItem(ctx context.Context, shelfID, bookID primitive.ObjectID) error
+	// RemoveBook takes a book off the shelves of every user
+	RemoveBook(ctx context.Context, bookID primitive.ObjectID) error
+	// StreamShelvings calls fn with every book on a shelf of any user, stopping at the first error
+	StreamShelvings(ctx context.Context, fn func(shelving Shelving) error) error
+}
+
+// sortShelves orders shelves as DefaultShelves does, then custom shelves by name ignoring case
+func sortShelves(shelves []entity.Shelves) {
+	rank := func(kind string) int {
+		for i, shelf := range entity.DefaultShelves {
+			if shelf.Kind == kind {
+				return i
+			}
+		}
+		return len(entity.DefaultShelves)
+	}
+	sort.SliceStable(shelves, func(i, j int) bool {
+		if ri, rj := rank(shelves[i].Kind), rank(shelves[j].Kind); ri != rj {
+			return ri < rj
+		}
+		return strings.ToLower(shelves[i].Name) < strings.ToLower(shelves[j].Name)
+	})
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryShelfRepository struct {
+	mu      sync.RWMutex
+	shelves map[primitive.ObjectID]entity.Shelves
+	items   map[primitive.ObjectID]entity.ShelfItems
+}
+
+// NewMemoryShelfRepository returns a ShelfRepository that keeps shelves in memory, useful for tests and local demos
+func NewMemoryShelfRepository() ShelfRepository {
+	return &memoryShelfRepository{
+		shelves: map[primitive.ObjectID]entity.Shelves{},
+		items:   map[primitive.ObjectID]entity.ShelfItems{},
+	}
+}
+
+func (r *memoryShelfRepository) EnsureDefaults(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, shelf := range entity.DefaultShelves {
+		exists := false
+		for _, stored := range r.shelves {
+			if stored.UserID == userID && stored.Kind == shelf.Kind {
+				exists = true
+				break
+			}
+		}
+		if exists || r.nameTaken(userID, shelf.Name, primitive.NilObjectID) {
+			continue
+		}
+
+		shelf.ID = primitive.NewObjectID()
+		shelf.UserID = userID
+		shelf.CreatedAt = time.Now().UTC()
+		r.shelves[shelf.ID] = shelf
+	}
+	return nil
+}
+
+func (r *memoryShelfRepository) Create(ctx context.Context, userID string, shelf entity.Shelf) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(userID, shelf.Name, primitive.NilObjectID) {
+		return primitive.NilObjectID, ErrDuplicateShelf
+	}
+
+	stored := entity.Shelves{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Kind:      entity.ShelfCustom,
+		Name:      shelf.Name,
+		Public:    shelf.Public,
+		CreatedAt: time.Now().UTC(),
+	}
+	r.shelves[stored.ID] = stored
+	return stored.ID, nil
+}
+
+func (r *memoryShelfRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Shelves, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	shelf, ok := r.shelves[id]
+	if !ok {
+		return entity.Shelves{}, ErrShelfNotFound
+	}
+	shelf.BookCount = r.countItems(id)
+	return shelf, nil
+}
+
+func (r *memoryShelfRepository) ListByUser(ctx context.Context, userID string, publicOnly bool) ([]entity.Shelves, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	shelves := []entity.Shelves{}
+	for _, shelf := range r.shelves {
+		if shelf.UserID == userID && (shelf.Public || !publicOnly) {
+			shelf.BookCount = r.countItems(shelf.ID)
+			shelves = append(shelves, shelf)
+		}
+	}
+	sortShelves(shelves)
+	return shelves, nil
+}
+
+func (r *memoryShelfRepository) Update(ctx context.Context, id primitive.ObjectID, shelf entity.Shelf) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.shelves[id]
+	if !ok {
+		return ErrShelfNotFound
+	}
+	if r.nameTaken(stored.UserID, shelf.Name, id) {
+		return ErrDuplicateShelf
+	}
+
+	stored.Name = shelf.Name
+	stored.Public = shelf.Public
+	updatedAt := time.Now().UTC()
+	stored.UpdatedAt = &updatedAt
+	r.shelves[id] = stored
+	return nil
+}
+
+func (r *memoryShelfRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.shelves[id]; !ok {
+		return ErrShelfNotFound
+	}
+	delete(r.shelves, id)
+	for itemID, item := range r.items {
+		if item.ShelfID == id {
+			delete(r.items, itemID)
+		}
+	}
+	return nil
+}
+
+func (r *memoryShelfRepository) AddItem(ctx context.Context, item entity.ShelfItems) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.findItem(item.ShelfID, item.BookID); ok {
+		return primitive.NilObjectID, ErrDuplicateShelfItem
+	}
+
+	item.ID = primitive.NewObjectID()
+	r.items[item.ID] = item
+	return item.ID, nil
+}
+
+func (r *memoryShelfRepository) GetItem(ctx context.Context, shelfID, bookID primitive.ObjectID) (entity.ShelfItems, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.findItem(shelfID, bookID)
+	if !ok {
+		return entity.ShelfItems{}, ErrShelfItemNotFound
+	}
+	return item, nil
+}
+
+func (r *memoryShelfRepository) ListItems(ctx context.Context, shelfID primitive.ObjectID, page, limit int64) (ShelfItemPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []entity.ShelfItems{}
+	for _, item := range r.items {
+		if item.ShelfID == shelfID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].AddedAt.Equal(items[j].AddedAt) {
+			return items[i].AddedAt.After(items[j].AddedAt)
+		}
+		return items[i].ID.Hex() > items[j].ID.Hex()
+	})
+
+	result := ShelfItemPage{Items: []entity.ShelfItems{}, Total: int64(len(items))}
+	start := (page - 1) * limit
+	if start < int64(len(items)) {
+		end := min(start+limit, int64(len(items)))
+		result.Items = items[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryShelfRepository) UpdateItem(ctx context.Context, shelfID, bookID primitive.ObjectID, item entity.ShelfItems) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.findItem(shelfID, bookID)
+	if !ok {
+		return ErrShelfItemNotFound
+	}
+	stored.StartedAt = item.StartedAt
+	stored.FinishedAt = item.FinishedAt
+	stored.Notes = item.Notes
+	updatedAt := time.Now().UTC()
+	stored.UpdatedAt = &updatedAt
+	r.items[stored.ID] = stored
+	return nil
+}
+
+func (r *memoryShelfRepository) RemoveItem(ctx context.Context, shelfID, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.findItem(shelfID, bookID)
+	if !ok {
+		return ErrShelfItemNotFound
+	}
+	delete(r.items, item.ID)
+	return nil
+}
+
+func (r *memoryShelfRepository) RemoveBook(ctx context.Context, bookID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if item.BookID == bookID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryShelfRepository) StreamShelvings(ctx context.Context, fn func(shelving Shelving) error) error {
+	r.mu.RLock()
+	shelvings := []Shelving{}
+	for _, item := range r.items {
+		shelvings = append(shelvings, Shelving{
+			UserID:  r.shelves[item.ShelfID].UserID,
+			ShelfID: item.ShelfID,
+			BookID:  item.BookID,
+		})
+	}
+	r.mu.RUnlock()
+
+	for _, shelving := range shelvings {
+		if err := fn(shelving); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nameTaken reports whether another shelf of the user than except has the name, ignoring case
+func (r *memoryShelfRepository) nameTaken(userID, name string, except primitive.ObjectID) bool {
+	for _, shelf := range r.shelves {
+		if shelf.UserID == userID && shelf.ID != except && strings.EqualFold(shelf.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryShelfRepository) findItem(shelfID, bookID primitive.ObjectID) (entity.ShelfItems, bool) {
+	for _, item := range r.items {
+		if item.ShelfID == shelfID && item.BookID == bookID {
+			return item, true
+		}
+	}
+	return entity.ShelfItems{}, false
+}
+
+func (r *memoryShelfRepository) countItems(shelfID primitive.ObjectID) int64 {
+	count := int64(0)
+	for _, item := range r.items {
+		if item.ShelfID == shelfID {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	shelvesCollection    = "shelves"
+	shelfItemsCollection = "shelf_items"
+)
+
+type mongoShelfRepository struct {
+	shelves *mongo.Collection
+	items   *mongo.Collection
+}
+
+// NewMongoShelfRepository returns a ShelfRepository backed by the shelves and shelf_items collections of the given database.
+// Shelf names are unique per user ignoring case through the index of migration 16.
+func NewMongoShelfRepository(database *mongo.Database) ShelfRepository {
+	return &mongoShelfRepository{
+		shelves: database.Collection(shelvesCollection),
+		items:   database.Collection(shelfItemsCollection),
+	}
+}
+
+func (r *mongoShelfRepository) EnsureDefaults(ctx context.Context, userID string) error {
+	for _, shelf := range entity.DefaultShelves {
+		_, err := r.shelves.UpdateOne(ctx,
+			bson.M{"userId": userID, "kind": shelf.Kind},
+			bson.M{"$setOnInsert": bson.M{"name": shelf.Name, "public": false, "createdAt": time.Now().UTC()}},
+			options.Update().SetUpsert(true),
+		)
+		// a concurrent request created the shelf first
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mongoShelfRepository) Create(ctx context.Context, userID string, shelf entity.Shelf) (primitive.ObjectID, error) {
+	document := entity.Shelves{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Kind:      entity.ShelfCustom,
+		Name:      shelf.Name,
+		Public:    shelf.Public,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	_, err := r.shelves.InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrDuplicateShelf
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return document.ID, nil
+}
+
+func (r *mongoShelfRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Shelves, error) {
+	var shelf entity.Shelves
+	err := r.shelves.FindOne(ctx, bson.M{"_id": id}).Decode(&shelf)
+	if err == mongo.ErrNoDocuments {
+		return shelf, ErrShelfNotFound
+	}
+	if err != nil {
+		return shelf, err
+	}
+
+	shelf.BookCount, err = r.items.CountDocuments(ctx, bson.M{"shelfId": id})
+	return shelf, err
+}
+
+func (r *mongoShelfRepository) ListByUser(ctx context.Context, userID string, publicOnly bool) ([]entity.Shelves, error) {
+	shelves := []entity.Shelves{}
+	filter := bson.M{"userId": userID}
+	if publicOnly {
+		filter["public"] = true
+	}
+
+	cursor, err := r.shelves.Find(ctx, filter)
+	if err != nil {
+		return shelves, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &shelves); err != nil {
+		return shelves, err
+	}
+
+	ids := make([]primitive.ObjectID, len(shelves))
+	for i, shelf := range shelves {
+		ids[i] = shelf.ID
+	}
+	counts, err := r.items.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"shelfId": bson.M{"$in": ids}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$shelfId", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return shelves, err
+	}
+	defer counts.Close(ctx)
+
+	var rows []struct {
+		ShelfID primitive.ObjectID `bson:"_id"`
+		Count   int64              `bson:"count"`
+	}
+	if err := counts.All(ctx, &rows); err != nil {
+		return shelves, err
+	}
+	byShelf := map[primitive.ObjectID]int64{}
+	for _, row := range rows {
+		byShelf[row.ShelfID] = row.Count
+	}
+	for i := range shelves {
+		shelves[i].BookCount = byShelf[shelves[i].ID]
+	}
+
+	sortShelves(shelves)
+	return shelves, nil
+}
+
+func (r *mongoShelfRepository) Update(ctx context.Context, id primitive.ObjectID, shelf entity.Shelf) error {
+	result, err := r.shelves.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"name":      shelf.Name,
+		"public":    shelf.Public,
+		"updatedAt": time.Now().UTC(),
+	}})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateShelf
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrShelfNotFound
+	}
+	return nil
+}
+
+func (r *mongoShelfRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.shelves.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrShelfNotFound
+	}
+
+	_, err = r.items.DeleteMany(ctx, bson.M{"shelfId": id})
+	return err
+}
+
+func (r *mongoShelfRepository) AddItem(ctx context.Context, item entity.ShelfItems) (primitive.ObjectID, error) {
+	item.ID = primitive.NewObjectID()
+	_, err := r.items.InsertOne(ctx, item)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrDuplicateShelfItem
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return item.ID, nil
+}
+
+func (r *mongoShelfRepository) GetItem(ctx context.Context, shelfID, bookID primitive.ObjectID) (entity.ShelfItems, error) {
+	var item entity.ShelfItems
+	err := r.items.FindOne(ctx, bson.M{"shelfId": shelfID, "bookId": bookID}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return item, ErrShelfItemNotFound
+	}
+	return item, err
+}
+
+func (r *mongoShelfRepository) ListItems(ctx context.Context, shelfID primitive.ObjectID, page, limit int64) (ShelfItemPage, error) {
+	result := ShelfItemPage{Items: []entity.ShelfItems{}}
+	filter := bson.M{"shelfId": shelfID}
+
+	total, err := r.items.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "addedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.items.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Items)
+	return result, err
+}
+
+func (r *mongoShelfRepository) UpdateItem(ctx context.Context, shelfID, bookID primitive.ObjectID, item entity.ShelfItems) error {
+	set := bson.M{"notes": item.Notes, "updatedAt": time.Now().UTC()}
+	unset := bson.M{}
+	if item.StartedAt != nil {
+		set["startedAt"] = item.StartedAt
+	} else {
+		unset["startedAt"] = ""
+	}
+	if item.FinishedAt != nil {
+		set["finishedAt"] = item.FinishedAt
+	} else {
+		unset["finishedAt"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.items.UpdateOne(ctx, bson.M{"shelfId": shelfID, "bookId": bookID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrShelfItemNotFound
+	}
+	return nil
+}
+
+func (r *mongoShelfRepository) RemoveItem(ctx context.Context, shelfID, bookID primitive.ObjectID) error {
+	result, err := r.items.DeleteOne(ctx, bson.M{"shelfId": shelfID, "bookId": bookID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrShelfItemNotFound
+	}
+	return nil
+}
+
+func (r *mongoShelfRepository) RemoveBook(ctx context.Context, bookID primitive.ObjectID) error {
+	_, err := r.items.DeleteMany(ctx, bson.M{"bookId": bookID})
+	return err
+}
+
+func (r *mongoShelfRepository) StreamShelvings(ctx context.Context, fn func(shelving Shelving) error) error {
+	cursor, err := r.items.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{"from": shelvesCollection, "localField": "shelfId", "foreignField": "_id", "as": "shelf"}}},
+		{{Key: "$unwind", Value: "$shelf"}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "userId": "$shelf.userId", "shelfId": 1, "bookId": 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			UserID  string             `bson:"userId"`
+			ShelfID primitive.ObjectID `bson:"shelfId"`
+			BookID  primitive.ObjectID `bson:"bookId"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return err
+		}
+		if err := fn(Shelving{UserID: row.UserID, ShelfID: row.ShelfID, BookID: row.BookID}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// Transactor runs several repository writes as a single unit. The repositories called with the ctx
+// given to fn take part in the transaction, fn returning an error aborts it.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "context"
+
+type memoryTransactor struct{}
+
+// NewMemoryTransactor returns a Transactor for the memory repositories, useful for tests and local demos.
+// The memory repositories cannot roll back, writes made before fn fails are kept.
+func NewMemoryTransactor() Transactor {
+	return memoryTransactor{}
+}
+
+func (memoryTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
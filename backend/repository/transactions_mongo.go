@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoTransactor struct {
+	database *mongo.Database
+}
+
+// NewMongoTransactor returns a Transactor running multi-document transactions on the given database,
+// MongoDB must run as a replica set
+func NewMongoTransactor(database *mongo.Database) Transactor {
+	return &mongoTransactor{database: database}
+}
+
+func (t *mongoTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, t.database, func(sc mongo.SessionContext) error {
+		return fn(sc)
+	})
+}
+
+// transaction runs fn in a multi-document transaction, retrying on transient errors.
+// Called within a transaction it runs fn in that transaction.
+func transaction(ctx context.Context, database *mongo.Database, fn func(sc mongo.SessionContext) error) error {
+	if sc, ok := ctx.(mongo.SessionContext); ok {
+		return fn(sc)
+	}
+
+	session, err := database.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
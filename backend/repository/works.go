@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"library-books/entity"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrWorkNotFound is returned when no work matches the requested ID
+var ErrWorkNotFound = errors.New("work not found")
+
+// WorkPage is a single page of works ordered by title
+type WorkPage struct {
+	Works []entity.Works
+	Total int64
+}
+
+// WorkRepository stores the works that group the editions of a book
+type WorkRepository interface {
+	Create(ctx context.Context, work entity.Work) (primitive.ObjectID, error)
+	Get(ctx context.Context, id primitive.ObjectID) (entity.Works, error)
+	// List pages through the works whose title contains the query ignoring case, an empty query lists every work
+	List(ctx context.Context, query string, page, limit int64) (WorkPage, error)
+	// ListBySeries returns the works of a series ordered by their position in it
+	ListBySeries(ctx context.Context, seriesID primitive.ObjectID) ([]entity.Works, error)
+	Update(ctx context.Context, id primitive.ObjectID, work entity.Work) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// sortBySeries orders works by their position in a series, works at the same position by ID
+func sortBySeries(works []entity.Works, seriesID primitive.ObjectID) {
+	sort.SliceStable(works, func(i, j int) bool {
+		left, _ := works[i].Position(seriesID)
+		right, _ := works[j].Position(seriesID)
+		if left != right {
+			return left < right
+		}
+		return works[i].ID.Hex() < works[j].ID.Hex()
+	})
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryWorkRepository struct {
+	mu    sync.RWMutex
+	works map[primitive.ObjectID]entity.Works
+}
+
+// NewMemoryWorkRepository returns a WorkRepository that keeps works in memory, useful for tests and local demos
+func NewMemoryWorkRepository() WorkRepository {
+	return &memoryWorkRepository{works: map[primitive.ObjectID]entity.Works{}}
+}
+
+func (r *memoryWorkRepository) Create(ctx context.Context, work entity.Work) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := work.Works(primitive.NewObjectID())
+	stored.CreatedAt = time.Now().UTC()
+	r.works[stored.ID] = stored
+	return stored.ID, nil
+}
+
+func (r *memoryWorkRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Works, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.works[id]
+	if !ok {
+		return entity.Works{}, ErrWorkNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryWorkRepository) List(ctx context.Context, query string, page, limit int64) (WorkPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	works := []entity.Works{}
+	for _, stored := range r.works {
+		if strings.Contains(strings.ToLower(stored.Title), strings.ToLower(query)) {
+			works = append(works, stored)
+		}
+	}
+	sort.Slice(works, func(i, j int) bool {
+		if works[i].Title != works[j].Title {
+			return works[i].Title < works[j].Title
+		}
+		return works[i].ID.Hex() < works[j].ID.Hex()
+	})
+
+	result := WorkPage{Works: []entity.Works{}, Total: int64(len(works))}
+	start := (page - 1) * limit
+	if start < int64(len(works)) {
+		end := min(start+limit, int64(len(works)))
+		result.Works = works[start:end]
+	}
+	return result, nil
+}
+
+func (r *memoryWorkRepository) ListBySeries(ctx context.Context, seriesID primitive.ObjectID) ([]entity.Works, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	works := []entity.Works{}
+	for _, stored := range r.works {
+		if _, ok := stored.Position(seriesID); ok {
+			works = append(works, stored)
+		}
+	}
+	sortBySeries(works, seriesID)
+	return works, nil
+}
+
+func (r *memoryWorkRepository) Update(ctx context.Context, id primitive.ObjectID, work entity.Work) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.works[id]
+	if !ok {
+		return ErrWorkNotFound
+	}
+
+	updated := work.Works(id)
+	updated.CreatedAt = stored.CreatedAt
+	updatedAt := time.Now().UTC()
+	updated.UpdatedAt = &updatedAt
+	r.works[id] = updated
+	return nil
+}
+
+func (r *memoryWorkRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.works[id]; !ok {
+		return ErrWorkNotFound
+	}
+	delete(r.works, id)
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"library-books/entity"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const worksCollection = "works"
+
+type mongoWorkRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoWorkRepository returns a WorkRepository backed by the works collection of the given database
+func NewMongoWorkRepository(database *mongo.Database) WorkRepository {
+	return &mongoWorkRepository{collection: database.Collection(worksCollection)}
+}
+
+func (r *mongoWorkRepository) Create(ctx context.Context, work entity.Work) (primitive.ObjectID, error) {
+	document := work.Works(primitive.NewObjectID())
+	document.CreatedAt = time.Now().UTC()
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return document.ID, nil
+}
+
+func (r *mongoWorkRepository) Get(ctx context.Context, id primitive.ObjectID) (entity.Works, error) {
+	var work entity.Works
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&work)
+	if err == mongo.ErrNoDocuments {
+		return work, ErrWorkNotFound
+	}
+	return work, err
+}
+
+func (r *mongoWorkRepository) List(ctx context.Context, query string, page, limit int64) (WorkPage, error) {
+	result := WorkPage{Works: []entity.Works{}}
+	filter := bson.M{}
+	if query != "" {
+		filter["title"] = bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	result.Total = total
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	err = cursor.All(ctx, &result.Works)
+	return result, err
+}
+
+func (r *mongoWorkRepository) ListBySeries(ctx context.Context, seriesID primitive.ObjectID) ([]entity.Works, error) {
+	works := []entity.Works{}
+	cursor, err := r.collection.Find(ctx, bson.M{"series.seriesId": seriesID})
+	if err != nil {
+		return works, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &works); err != nil {
+		return works, err
+	}
+	sortBySeries(works, seriesID)
+	return works, nil
+}
+
+func (r *mongoWorkRepository) Update(ctx context.Context, id primitive.ObjectID, work entity.Work) error {
+	set := bson.M{
+		"title":       work.Title,
+		"authorIds":   work.AuthorIDs,
+		"description": work.Description,
+		"series":      work.Series,
+		"updatedAt":   time.Now().UTC(),
+	}
+	unset := bson.M{}
+	if work.OriginalLanguage != "" {
+		set["originalLanguage"] = work.OriginalLanguage
+	} else {
+		unset["originalLanguage"] = ""
+	}
+	if work.FirstPublished != 0 {
+		set["firstPublished"] = work.FirstPublished
+	} else {
+		unset["firstPublished"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrWorkNotFound
+	}
+	return nil
+}
+
+func (r *mongoWorkRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrWorkNotFound
+	}
+	return nil
+}
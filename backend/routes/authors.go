@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"library-books/controllers/authors"
+	"library-books/entity"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AuthorsRoutes(route *gin.RouterGroup, authorsController *authors.AuthorsController) {
+	route.GET("/", authorsController.GetAuthorsHandler)
+	route.GET("/:id", authorsController.GetAuthorHandler)
+	route.GET("/:id/books", authorsController.GetAuthorBooksHandler)
+
+	// authority records are managed by admins
+	admin := route.Group("", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleAdmin))
+	admin.POST("/", authorsController.AddAuthorHandler)
+	admin.PUT("/:id", authorsController.UpdateAuthorHandler)
+	admin.DELETE("/:id", authorsController.DeleteAuthorHandler)
+}
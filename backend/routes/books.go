@@ -2,16 +2,40 @@ package routes
 
 import (
 	"library-books/controllers/books"
+	"library-books/entity"
+	"library-books/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func BooksRoutes(route *gin.RouterGroup, booksController *books.BooksController) {
-	route.POST("/", booksController.AddBookHandler)
-	route.GET("/", booksController.GetAllBookHandler)
-	route.GET("/:id", booksController.GetBookHandler)
-	route.PUT("/:id", booksController.UpdateBookHandler)
-	route.DELETE("/:id", booksController.DeleteBookHandler)
+func BooksRoutes(route *gin.RouterGroup, booksController *books.BooksController, httpCache *middleware.HTTPCache) {
+	route.GET("/", httpCache.Middleware(), booksController.GetAllBookHandler)
+	route.GET("/export", booksController.ExportBooksHandler)
+	route.GET("/search", httpCache.Middleware(), booksController.SearchBookHandler)
+	route.GET("/isbn/:isbn", httpCache.Middleware(), booksController.GetBookByISBNHandler)
+	route.GET("/:id", httpCache.Middleware(), booksController.GetBookHandler)
+	route.GET("/:id/copies", booksController.GetCopiesHandler)
+	route.GET("/:id/copies/:copyId", booksController.GetCopyHandler)
+	route.GET("/:id/reviews", booksController.GetReviewsHandler)
+	route.POST("/:id/reviews", middleware.AuthMiddleware(), booksController.AddReviewHandler)
+	route.PUT("/:id/reviews/:reviewId", middleware.AuthMiddleware(), booksController.UpdateReviewHandler)
+	route.DELETE("/:id/reviews/:reviewId", middleware.AuthMiddleware(), booksController.DeleteReviewHandler)
 
 	route.POST("/url", booksController.AddUrlHandler)
+
+	// the catalogue and the copies are managed by the library staff, every revision has the acting user
+	staff := route.Group("", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleLibrarian, entity.RoleAdmin))
+	staff.POST("/", booksController.AddBookHandler)
+	staff.POST("/import", booksController.ImportBooksHandler)
+	staff.PUT("/:id", booksController.UpdateBookHandler)
+	staff.PATCH("/:id", booksController.PatchBookHandler)
+	staff.DELETE("/:id", booksController.DeleteBookHandler)
+	staff.GET("/trash", booksController.GetTrashBookHandler)
+	staff.GET("/:id/history", booksController.GetBookHistoryHandler)
+	staff.POST("/:id/restore", booksController.RestoreBookHandler)
+	staff.POST("/:id/revert/:revision", booksController.RevertBookHandler)
+	staff.POST("/:id/copies", booksController.AddCopyHandler)
+	staff.PUT("/:id/copies/:copyId", booksController.UpdateCopyHandler)
+	staff.DELETE("/:id/copies/:copyId", booksController.DeleteCopyHandler)
+	staff.POST("/:id/cover", booksController.UploadCoverHandler)
 }
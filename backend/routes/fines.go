@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"library-books/controllers/loans"
+
+	"github.com/gin-gonic/gin"
+)
+
+func FinesRoutes(route *gin.RouterGroup, loansController *loans.LoansController) {
+	route.GET("/:userId", loansController.GetUserFinesHandler)
+	route.GET("/:userId/entries", loansController.GetUserFineEntriesHandler)
+	route.POST("/:userId/waive", loansController.WaiveFineHandler)
+	route.POST("/:userId/adjust", loansController.AdjustFineHandler)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"library-books/controllers/genres"
+	"library-books/entity"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GenresRoutes(route *gin.RouterGroup, genresController *genres.GenresController) {
+	route.GET("/", genresController.GetGenresHandler)
+	route.GET("/:slug", genresController.GetGenreHandler)
+
+	// the taxonomy is managed by admins
+	admin := route.Group("", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleAdmin))
+	admin.POST("/", genresController.AddGenreHandler)
+	admin.PUT("/:slug", genresController.UpdateGenreHandler)
+	admin.DELETE("/:slug", genresController.DeleteGenreHandler)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"library-books/controllers/loans"
+
+	"github.com/gin-gonic/gin"
+)
+
+func HoldsRoutes(route *gin.RouterGroup, loansController *loans.LoansController) {
+	route.POST("/", loansController.PlaceHoldHandler)
+	route.GET("/:id", loansController.GetHoldHandler)
+	route.DELETE("/:id", loansController.CancelHoldHandler)
+}
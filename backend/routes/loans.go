@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"library-books/controllers/loans"
+	"library-books/entity"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LoansRoutes(route *gin.RouterGroup, loansController *loans.LoansController) {
+	route.POST("/", loansController.CheckoutHandler)
+	route.POST("/:id/renew", loansController.RenewHandler)
+
+	// copies are checked back in at the desk
+	route.POST("/:id/return", middleware.RequireRole(entity.RoleLibrarian, entity.RoleAdmin), loansController.ReturnHandler)
+}
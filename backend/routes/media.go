@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"library-books/controllers/media"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MediaRoutes(route *gin.RouterGroup, mediaController *media.MediaController, httpCache *middleware.HTTPCache) {
+	route.GET("/covers/:id", httpCache.Middleware(), mediaController.GetCoverHandler)
+	route.GET("/covers/:id/:upload", httpCache.Middleware(), mediaController.GetCoverHandler)
+}
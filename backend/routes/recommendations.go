@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"library-books/controllers/recommendations"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RecommendationsRoutes(booksRoute *gin.RouterGroup, usersRoute *gin.RouterGroup, recommendationsController *recommendations.RecommendationsController, httpCache *middleware.HTTPCache) {
+	booksRoute.GET("/:id/similar", httpCache.Middleware(), recommendationsController.GetSimilarBooksHandler)
+	usersRoute.GET("/recommendations", recommendationsController.GetUserRecommendationsHandler)
+}
@@ -1,13 +1,32 @@
 package routes
 
 import (
+	"context"
+	"fmt"
+	"library-books/config"
+	"library-books/controllers/authors"
 	"library-books/controllers/books"
+	"library-books/controllers/genres"
+	"library-books/controllers/loans"
+	"library-books/controllers/media"
+	"library-books/controllers/recommendations"
+	"library-books/controllers/shelves"
 	"library-books/controllers/users"
+	"library-books/controllers/works"
+	"library-books/database/migrations"
 	"library-books/database/mongodb"
 	_ "library-books/docs" // docs is generated by Swag CLI, you have to import it.
+	"library-books/entity"
 	"library-books/helpers"
+	"library-books/jobs"
 	"library-books/middleware"
+	"library-books/repository"
+	"library-books/services"
+	"library-books/storage"
+	"log"
 	"net/http"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -23,6 +42,18 @@ func SetupRouter(validate *validator.Validate) *gin.Engine {
 	// connection mongodb database
 	mongodb.Connect()
 
+	// apply pending schema migrations, they can also be run with "go run main.go migrate"
+	config := config.ConfigViper()
+	if config.GetBool("database.migrate") {
+		applied, err := migrations.NewMigrator(mongodb.Database).Up(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, migration := range applied {
+			log.Printf("Applied migration %d %s", migration.Version, migration.Description)
+		}
+	}
+
 	// skip base url path
 	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{
 		SkipPaths: []string{"/"},
@@ -42,8 +73,8 @@ func SetupRouter(validate *validator.Validate) *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"},
 		AllowMethods:     []string{"GET", "PUT", "PATCH", "DELETE", "POST", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "If-Match", "If-None-Match", "If-Modified-Since"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Last-Modified"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -65,18 +96,259 @@ func SetupRouter(validate *validator.Validate) *gin.Engine {
 	// endpoint swagger
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// circulation policy, loans default to two weeks and held copies wait three days for pickup
+	loanPeriod := config.GetDuration("loans.period")
+	if loanPeriod <= 0 {
+		loanPeriod = 14 * 24 * time.Hour
+	}
+	pickupWindow := config.GetDuration("holds.pickupWindow")
+	if pickupWindow <= 0 {
+		pickupWindow = 72 * time.Hour
+	}
+	bookRepository := repository.NewMongoBookRepository(mongodb.Database)
+	copyRepository := repository.NewMongoCopyRepository(mongodb.Database)
+	holdRepository := repository.NewMongoHoldRepository(mongodb.Database)
+	authorRepository := repository.NewMongoAuthorRepository(mongodb.Database)
+	genreRepository := repository.NewMongoGenreRepository(mongodb.Database)
+	workRepository := repository.NewMongoWorkRepository(mongodb.Database)
+	seriesRepository := repository.NewMongoSeriesRepository(mongodb.Database)
+	shelfRepository := repository.NewMongoShelfRepository(mongodb.Database)
+	loanRepository := repository.NewMongoLoanRepository(mongodb.Database)
+	recommendationRepository := repository.NewMongoRecommendationRepository(mongodb.Database)
+	revisionRepository := repository.NewMongoRevisionRepository(mongodb.Database)
+	reviewRepository := repository.NewMongoReviewRepository(mongodb.Database)
+	loansController := &loans.LoansController{
+		Validate: validate,
+		Books:    bookRepository,
+		Copies:   copyRepository,
+		Loans:    loanRepository,
+		Holds:    holdRepository,
+		Fines:    repository.NewMongoFineRepository(mongodb.Database),
+		Policy: loans.Policy{
+			Period:       loanPeriod,
+			MaxRenewals:  config.GetInt("loans.maxRenewals"),
+			MaxActive:    config.GetInt("loans.maxActive"),
+			PickupWindow: pickupWindow,
+			Fines:        finePolicy(config),
+			FineLimit:    config.GetFloat64("fines.blockThreshold"),
+		},
+	}
+
+	// pass the copies of holds not picked up in time to the next patron
+	expiryInterval := config.GetDuration("holds.expiryInterval")
+	if expiryInterval <= 0 {
+		expiryInterval = 15 * time.Minute
+	}
+	jobs.StartHoldExpiry(context.Background(), holdRepository, pickupWindow, expiryInterval)
+
+	// score similar books and reader recommendations from the catalogue, the shelves and the loans
+	recommendationInterval := config.GetDuration("recommendations.refreshInterval")
+	if recommendationInterval <= 0 {
+		recommendationInterval = time.Hour
+	}
+	jobs.StartRecommendations(context.Background(), bookRepository, shelfRepository, loanRepository, recommendationRepository, recommendationInterval)
+
+	// uploaded cover images, at most 5 MiB unless covers.maxSize says otherwise
+	coverStore, err := coverStorage(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	coverCacheDirectory := config.GetString("covers.cacheDirectory")
+	if coverCacheDirectory == "" {
+		coverCacheDirectory = "cache"
+	}
+	coverCache, err := storage.NewLocalBlobStore(coverCacheDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// permanently remove books that stayed in the trash longer than the retention window, with their holds,
+	// reviews, history, shelf entries, recommendations and covers
+	if retention := config.GetDuration("books.trashRetention"); retention > 0 {
+		interval := config.GetDuration("books.trashPurgeInterval")
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		jobs.StartTrashPurge(context.Background(), bookRepository, jobs.TrashDependents{
+			Transactor:      repository.NewMongoTransactor(mongodb.Database),
+			Copies:          copyRepository,
+			Loans:           loanRepository,
+			Holds:           holdRepository,
+			Reviews:         reviewRepository,
+			Revisions:       revisionRepository,
+			Shelves:         shelfRepository,
+			Recommendations: recommendationRepository,
+			Covers:          coverStore,
+			CoverCache:      coverCache,
+		}, retention, interval)
+	}
+
+	coverSizes, err := resizeSizes(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// resizing is CPU bound, by default one cover is resized per CPU at a time
+	maxResizes := config.GetInt("covers.maxResizes")
+	if maxResizes <= 0 {
+		maxResizes = runtime.NumCPU()
+	}
+	coverPolicy := books.CoverPolicy{
+		MaxSize: int64(config.GetInt("covers.maxSize")),
+		BaseURL: strings.TrimSuffix(config.GetString("covers.baseUrl"), "/"),
+	}
+	if coverPolicy.MaxSize <= 0 {
+		coverPolicy.MaxSize = 5 << 20
+	}
+
+	// conditional GET and Cache-Control policies of the cacheable book routes
+	httpCache := middleware.NewHTTPCache(config.GetStringMapString("cache.routes"))
+
 	// endpoint for group api
 	group := router.Group("api/v1")
 	{
 		UsersGroup := group.Group("users", middleware.AuthMiddleware())
-		UsersRoutes(UsersGroup, &users.UsersController{Validate: validate})
+		UsersRoutes(UsersGroup, &users.UsersController{Validate: validate}, loansController)
+
+		ShelvesGroup := UsersGroup.Group("shelves")
+		ShelvesRoutes(ShelvesGroup, &shelves.ShelvesController{
+			Validate: validate,
+			Shelves:  shelfRepository,
+			Books:    bookRepository,
+		})
+
+		LoansGroup := group.Group("loans", middleware.AuthMiddleware())
+		LoansRoutes(LoansGroup, loansController)
+
+		HoldsGroup := group.Group("holds", middleware.AuthMiddleware())
+		HoldsRoutes(HoldsGroup, loansController)
+
+		FinesGroup := group.Group("fines", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleLibrarian, entity.RoleAdmin))
+		FinesRoutes(FinesGroup, loansController)
 
 		AuthUsersGroup := group.Group("auth")
 		AuthUsersRoutes(AuthUsersGroup, &users.UsersController{Validate: validate})
 
-		BooksGroup := group.Group("books")
-		BooksRoutes(BooksGroup, &books.BooksController{Validate: validate})
+		BooksGroup := group.Group("books", middleware.OptionalAuthMiddleware())
+		BooksRoutes(BooksGroup, &books.BooksController{
+			Validate:     validate,
+			Repository:   bookRepository,
+			Revisions:    revisionRepository,
+			Transactor:   repository.NewMongoTransactor(mongodb.Database),
+			Copies:       copyRepository,
+			Holds:        holdRepository,
+			Authors:      authorRepository,
+			Genres:       genreRepository,
+			Works:        workRepository,
+			Series:       seriesRepository,
+			Reviews:      reviewRepository,
+			Covers:       coverStore,
+			CoverCache:   coverCache,
+			CoverPolicy:  coverPolicy,
+			PickupWindow: pickupWindow,
+		}, httpCache)
+
+		RecommendationsRoutes(BooksGroup, UsersGroup, &recommendations.RecommendationsController{
+			Recommendations: recommendationRepository,
+			Books:           bookRepository,
+		}, httpCache)
+
+		AuthorsGroup := group.Group("authors", middleware.OptionalAuthMiddleware())
+		AuthorsRoutes(AuthorsGroup, &authors.AuthorsController{
+			Validate:   validate,
+			Repository: authorRepository,
+			Books:      bookRepository,
+		})
+
+		worksController := &works.WorksController{
+			Validate: validate,
+			Works:    workRepository,
+			Series:   seriesRepository,
+			Books:    bookRepository,
+			Authors:  authorRepository,
+		}
+		WorksGroup := group.Group("works", middleware.OptionalAuthMiddleware())
+		WorksRoutes(WorksGroup, worksController)
+
+		SeriesGroup := group.Group("series", middleware.OptionalAuthMiddleware())
+		SeriesRoutes(SeriesGroup, worksController)
+
+		GenresGroup := group.Group("genres")
+		GenresRoutes(GenresGroup, &genres.GenresController{
+			Validate:   validate,
+			Repository: genreRepository,
+			Books:      bookRepository,
+		})
 	}
 
+	// endpoint for uploaded media, outside the api base path so the URLs stay short
+	MediaGroup := router.Group("media")
+	MediaRoutes(MediaGroup, &media.MediaController{
+		Books:   bookRepository,
+		Covers:  coverStore,
+		Cache:   coverCache,
+		Sizes:   coverSizes,
+		Resizes: make(chan struct{}, maxResizes),
+	}, httpCache)
+
 	return router
 }
+
+// resizeSizes reads the sizes covers can be resized to from covers.sizes, written as "200x300",
+// services.DefaultCoverSizes are used when none is configured
+func resizeSizes(config config.KeyViperConfig) ([]services.CoverSize, error) {
+	values := config.GetStringSlice("covers.sizes")
+	if len(values) == 0 {
+		return services.DefaultCoverSizes, nil
+	}
+	sizes := make([]services.CoverSize, len(values))
+	for i, value := range values {
+		size, err := services.ParseCoverSize(value)
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = size
+	}
+	return sizes, nil
+}
+
+// coverStorage opens the blob store of the uploaded covers named by covers.storage in config.json, the
+// default "local" driver keeps them under covers.directory
+func coverStorage(config config.KeyViperConfig) (storage.BlobStore, error) {
+	switch driver := config.GetString("covers.storage"); driver {
+	case "", "local":
+		directory := config.GetString("covers.directory")
+		if directory == "" {
+			directory = "uploads"
+		}
+		return storage.NewLocalBlobStore(directory)
+	default:
+		return nil, fmt.Errorf("unknown covers storage %q", driver)
+	}
+}
+
+// finePolicy reads the late fees from the fines section of config.json, a material under fines.materials
+// overrides only the fields it sets
+func finePolicy(config config.KeyViperConfig) services.FinePolicy {
+	policy := services.FinePolicy{
+		Default:   fineRule(config, "fines", services.FineRule{}),
+		Materials: map[string]services.FineRule{},
+	}
+	for material := range config.GetStringMap("fines.materials") {
+		policy.Materials[material] = fineRule(config, "fines.materials."+material, policy.Default)
+	}
+	return policy
+}
+
+// fineRule reads a late fee under key, the fields missing from the config keep the values of base
+func fineRule(config config.KeyViperConfig, key string, base services.FineRule) services.FineRule {
+	fields := config.GetStringMap(key)
+	if _, ok := fields["rate"]; ok {
+		base.Rate = config.GetFloat64(key + ".rate")
+	}
+	if _, ok := fields["gracedays"]; ok {
+		base.GraceDays = config.GetInt(key + ".graceDays")
+	}
+	if _, ok := fields["cap"]; ok {
+		base.Cap = config.GetFloat64(key + ".cap")
+	}
+	return base
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"library-books/controllers/shelves"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ShelvesRoutes(route *gin.RouterGroup, shelvesController *shelves.ShelvesController) {
+	route.GET("/", shelvesController.GetShelvesHandler)
+	route.POST("/", shelvesController.AddShelfHandler)
+	route.GET("/:id", shelvesController.GetShelfHandler)
+	route.PUT("/:id", shelvesController.UpdateShelfHandler)
+	route.DELETE("/:id", shelvesController.DeleteShelfHandler)
+	route.GET("/:id/books", shelvesController.GetShelfBooksHandler)
+	route.POST("/:id/books", shelvesController.AddShelfBookHandler)
+	route.PUT("/:id/books/:bookId", shelvesController.UpdateShelfBookHandler)
+	route.DELETE("/:id/books/:bookId", shelvesController.RemoveShelfBookHandler)
+}
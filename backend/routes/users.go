@@ -1,11 +1,16 @@
 package routes
 
 import (
+	"library-books/controllers/loans"
 	"library-books/controllers/users"
 
 	"github.com/gin-gonic/gin"
 )
 
-func UsersRoutes(route *gin.RouterGroup, usersController *users.UsersController) {
+func UsersRoutes(route *gin.RouterGroup, usersController *users.UsersController, loansController *loans.LoansController) {
 	route.GET("/profile", usersController.ProfileHandler)
+	route.GET("/profile/loans", loansController.GetProfileLoansHandler)
+	route.GET("/profile/holds", loansController.GetProfileHoldsHandler)
+	route.GET("/profile/fines", loansController.GetProfileFinesHandler)
+	route.GET("/profile/fines/entries", loansController.GetProfileFineEntriesHandler)
 }
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"library-books/controllers/works"
+	"library-books/entity"
+	"library-books/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WorksRoutes(route *gin.RouterGroup, worksController *works.WorksController) {
+	route.GET("/", worksController.GetWorksHandler)
+	route.GET("/:id", worksController.GetWorkHandler)
+	route.GET("/:id/editions", worksController.GetWorkEditionsHandler)
+
+	// works and series are managed by admins, like the genres
+	admin := route.Group("", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleAdmin))
+	admin.POST("/", worksController.AddWorkHandler)
+	admin.PUT("/:id", worksController.UpdateWorkHandler)
+	admin.DELETE("/:id", worksController.DeleteWorkHandler)
+}
+
+func SeriesRoutes(route *gin.RouterGroup, worksController *works.WorksController) {
+	route.GET("/", worksController.GetSeriesListHandler)
+	route.GET("/:id", worksController.GetSeriesHandler)
+	route.GET("/:id/works", worksController.GetSeriesWorksHandler)
+
+	admin := route.Group("", middleware.AuthMiddleware(), middleware.RequireRole(entity.RoleAdmin))
+	admin.POST("/", worksController.AddSeriesHandler)
+	admin.PUT("/:id", worksController.UpdateSeriesHandler)
+	admin.DELETE("/:id", worksController.DeleteSeriesHandler)
+}
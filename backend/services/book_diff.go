@@ -0,0 +1,33 @@
+package services
+
+import (
+	"library-books/entity"
+	"reflect"
+	"strings"
+)
+
+// diffIgnoredFields are stamped by the server on every write and left out of a diff
+var diffIgnoredFields = map[string]bool{
+	"createdAt": true,
+	"updatedAt": true,
+}
+
+// DiffBooks returns the editable fields that differ between two versions of a book, in declaration order
+func DiffBooks(before, after entity.Book) []entity.FieldChange {
+	changes := []entity.FieldChange{}
+
+	beforeValue, afterValue := reflect.ValueOf(before), reflect.ValueOf(after)
+	bookType := beforeValue.Type()
+	for i := 0; i < bookType.NumField(); i++ {
+		name, _, _ := strings.Cut(bookType.Field(i).Tag.Get("json"), ",")
+		if name == "" || diffIgnoredFields[name] {
+			continue
+		}
+
+		from, to := beforeValue.Field(i).Interface(), afterValue.Field(i).Interface()
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, entity.FieldChange{Field: name, From: from, To: to})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"library-books/entity"
+	"strconv"
+	"time"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatJSON   = "json"
+)
+
+// ExportContentTypes maps every export format to its response content type
+var ExportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv; charset=utf-8",
+	ExportFormatNDJSON: "application/x-ndjson",
+	ExportFormatJSON:   "application/json; charset=utf-8",
+}
+
+// BookWriter streams books into an export file, Close must be called to complete the file
+type BookWriter interface {
+	Write(book entity.Books) error
+	Close() error
+}
+
+// NewBookWriter returns a writer for the given export format
+func NewBookWriter(format string, w io.Writer) (BookWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVBookWriter(w), nil
+	case ExportFormatNDJSON:
+		return &ndjsonBookWriter{encoder: json.NewEncoder(w)}, nil
+	case ExportFormatJSON:
+		return &jsonBookWriter{writer: w}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// csvExportColumns uses the same column names as the CSV import so exports can be imported back
+var csvExportColumns = []string{"id", "title", "author", "year", "isbn", "genre", "description", "coverImageUrl", "createdAt", "updatedAt"}
+
+type csvBookWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCSVBookWriter(w io.Writer) *csvBookWriter {
+	return &csvBookWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvBookWriter) Write(book entity.Books) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvExportColumns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	return w.writer.Write([]string{
+		book.ID.Hex(),
+		book.Title,
+		book.Author,
+		strconv.Itoa(book.Year),
+		book.ISBN,
+		book.Genre,
+		book.Description,
+		book.CoverImageUrl,
+		book.CreatedAt.Format(time.RFC3339),
+		formatOptionalTime(book.UpdatedAt),
+	})
+}
+
+// formatOptionalTime formats a timestamp as RFC 3339, leaving the cell empty when it is not set
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func (w *csvBookWriter) Close() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvExportColumns); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonBookWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonBookWriter) Write(book entity.Books) error {
+	return w.encoder.Encode(book)
+}
+
+func (w *ndjsonBookWriter) Close() error {
+	return nil
+}
+
+// jsonBookWriter writes a JSON array one element at a time
+type jsonBookWriter struct {
+	writer io.Writer
+	count  int
+}
+
+func (w *jsonBookWriter) Write(book entity.Books) error {
+	data, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if w.count == 0 {
+		separator = "["
+	}
+	w.count++
+
+	if _, err := io.WriteString(w.writer, separator); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(data)
+	return err
+}
+
+func (w *jsonBookWriter) Close() error {
+	closing := "]"
+	if w.count == 0 {
+		closing = "[]"
+	}
+	_, err := io.WriteString(w.writer, closing+"\n")
+	return err
+}
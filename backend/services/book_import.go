@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"library-books/entity"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// ErrMissingColumns is returned when a CSV header lacks a required book column
+var ErrMissingColumns = errors.New("csv header must contain title, author and year columns")
+
+// BookRow is a single parsed row of an import file, Err is set when the row cannot be read as a book
+type BookRow struct {
+	Line int
+	Book entity.Book
+	Err  error
+}
+
+// BookRowReader streams books from an import file, Next returns io.EOF after the last row
+type BookRowReader interface {
+	Next() (BookRow, error)
+}
+
+// NewBookRowReader returns a reader for the given import format
+func NewBookRowReader(format string, r io.Reader) (BookRowReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		return newCSVBookReader(r)
+	case ImportFormatNDJSON:
+		return newNDJSONBookReader(r), nil
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+type csvBookReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// newCSVBookReader reads the header row and maps the book columns by their JSON field names, case insensitive
+func newCSVBookReader(r io.Reader) (*csvBookReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"title", "author", "year"} {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrMissingColumns
+		}
+	}
+
+	return &csvBookReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvBookReader) Next() (BookRow, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return BookRow{}, io.EOF
+	}
+
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return BookRow{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+		return BookRow{}, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	column := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := BookRow{Line: line, Book: entity.Book{
+		Title:         column("title"),
+		Author:        column("author"),
+		ISBN:          column("isbn"),
+		Genre:         column("genre"),
+		Description:   column("description"),
+		CoverImageUrl: column("coverimageurl"),
+	}}
+
+	if year := column("year"); year != "" {
+		row.Book.Year, err = strconv.Atoi(year)
+		if err != nil {
+			row.Err = fmt.Errorf("invalid year %q", year)
+		}
+	}
+	return row, nil
+}
+
+type ndjsonBookReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// newNDJSONBookReader reads one JSON encoded book per line, blank lines are skipped
+func newNDJSONBookReader(r io.Reader) *ndjsonBookReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &ndjsonBookReader{scanner: scanner}
+}
+
+func (r *ndjsonBookReader) Next() (BookRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := BookRow{Line: r.line}
+		if err := json.Unmarshal([]byte(text), &row.Book); err != nil {
+			row.Err = err
+		}
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return BookRow{}, err
+	}
+	return BookRow{}, io.EOF
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	_ "image/png" // registers the PNG decoder
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registers the WebP decoder
+)
+
+// ErrUnsupportedCover is returned for uploaded covers that are not JPEG, PNG, GIF or WebP images
+var ErrUnsupportedCover = errors.New("unsupported cover image type")
+
+// ErrInvalidCover is returned for covers that cannot be decoded or have more than MaxCoverPixels pixels
+var ErrInvalidCover = errors.New("invalid cover image")
+
+// MaxCoverPixels bounds the decoded size of a cover, a small file can still hold a huge image
+const MaxCoverPixels = 40_000_000
+
+// coverQuality is the JPEG quality of the generated cover images
+const coverQuality = 85
+
+// * how a resized cover fills the requested box
+const (
+	// CoverFitContain fits the whole cover inside the box, one side may come out shorter
+	CoverFitContain = "contain"
+	// CoverFitCover fills the whole box, cropping the centre of the cover to its aspect ratio
+	CoverFitCover = "cover"
+)
+
+// * formats of the resized covers
+const (
+	CoverFormatJPEG = "jpeg"
+	// CoverFormatWebP is lossless WebP, the encoder does not support lossy compression
+	CoverFormatWebP = "webp"
+)
+
+// CoverSize is a box a cover can be resized to, a zero side is left free
+type CoverSize struct {
+	Width  int
+	Height int
+}
+
+// DefaultCoverSizes are the sizes a cover can be resized to when covers.sizes is not configured,
+// the widths of the thumbnails and three 2:3 boxes
+var DefaultCoverSizes = []CoverSize{
+	{Width: 160}, {Width: 320}, {Width: 640},
+	{Width: 100, Height: 150}, {Width: 200, Height: 300}, {Width: 400, Height: 600},
+}
+
+// ParseCoverSize reads a size written as "200x300", "200x0" leaves the height free
+func ParseCoverSize(value string) (CoverSize, error) {
+	width, height, ok := strings.Cut(value, "x")
+	w, errW := strconv.Atoi(width)
+	h, errH := strconv.Atoi(height)
+	if !ok || errW != nil || errH != nil || w < 0 || h < 0 || w+h == 0 {
+		return CoverSize{}, fmt.Errorf("invalid cover size %q", value)
+	}
+	return CoverSize{Width: w, Height: h}, nil
+}
+
+// CoverVariant is a resized rendition of a cover
+type CoverVariant struct {
+	CoverSize
+	Fit    string
+	Format string
+}
+
+// ContentType is the content type of the encoded variant
+func (v CoverVariant) ContentType() string {
+	if v.Format == CoverFormatWebP {
+		return "image/webp"
+	}
+	return "image/jpeg"
+}
+
+// Key is the blob key of the variant rendered from a cover upload of a book, next to the blobs of the upload.
+// source identifies the stored cover so a replaced cover never serves variants of the previous one.
+func (v CoverVariant) Key(bookID string, uploadID string, source string) string {
+	return CoverUploadPrefix(bookID, uploadID) + fmt.Sprintf("%s-%dx%d-%s.%s", source, v.Width, v.Height, v.Fit, v.Format)
+}
+
+// CoverTypes are the accepted cover content types, sniffed from the uploaded bytes
+var CoverTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// CoverThumbnail is a size generated for every uploaded cover, its images are at most Width pixels wide
+type CoverThumbnail struct {
+	Name  string
+	Width int
+}
+
+// CoverThumbnails are the thumbnails generated for every uploaded cover, smallest first
+var CoverThumbnails = []CoverThumbnail{
+	{Name: "small", Width: 160},
+	{Name: "medium", Width: 320},
+	{Name: "large", Width: 640},
+}
+
+// DecodeCover sniffs the content type of an uploaded cover and decodes it
+func DecodeCover(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	supported := false
+	for _, coverType := range CoverTypes {
+		supported = supported || coverType == contentType
+	}
+	if !supported {
+		return nil, contentType, ErrUnsupportedCover
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > MaxCoverPixels {
+		return nil, contentType, ErrInvalidCover
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, contentType, ErrInvalidCover
+	}
+	return img, contentType, nil
+}
+
+// ResizeCover scales an image down to fit within width and height keeping its aspect ratio, a zero
+// bound leaves that side free. Images are never enlarged. Transparent areas are laid on white, as the
+// resized covers are encoded as JPEG.
+func ResizeCover(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	scale := 1.0
+	if width > 0 && bounds.Dx() > width {
+		scale = float64(width) / float64(bounds.Dx())
+	}
+	if height > 0 && float64(bounds.Dy())*scale > float64(height) {
+		scale = float64(height) / float64(bounds.Dy())
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, max(1, int(float64(bounds.Dx())*scale+0.5)), max(1, int(float64(bounds.Dy())*scale+0.5))))
+	draw.Draw(resized, resized.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Over, nil)
+	return resized
+}
+
+// FitCover scales an image down for the box of the variant, cropping its centre for CoverFitCover.
+// Images are never enlarged, a cropped cover smaller than the box keeps the aspect ratio of the box.
+func FitCover(img image.Image, variant CoverVariant) image.Image {
+	if variant.Fit != CoverFitCover || variant.Width == 0 || variant.Height == 0 {
+		return ResizeCover(img, variant.Width, variant.Height)
+	}
+
+	// largest centred area of the image with the aspect ratio of the box
+	bounds := img.Bounds()
+	crop := bounds
+	if bounds.Dx()*variant.Height > bounds.Dy()*variant.Width {
+		width := bounds.Dy() * variant.Width / variant.Height
+		crop.Min.X += (bounds.Dx() - width) / 2
+		crop.Max.X = crop.Min.X + width
+	} else {
+		height := bounds.Dx() * variant.Height / variant.Width
+		crop.Min.Y += (bounds.Dy() - height) / 2
+		crop.Max.Y = crop.Min.Y + height
+	}
+
+	width, height := variant.Width, variant.Height
+	if crop.Dx() < width {
+		width, height = crop.Dx(), max(1, crop.Dx()*variant.Height/variant.Width)
+	}
+	resized := image.NewRGBA(image.Rect(0, 0, max(1, width), height))
+	draw.Draw(resized, resized.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, crop, draw.Over, nil)
+	return resized
+}
+
+// EncodeCover writes a cover image in the format of the variant
+func EncodeCover(w io.Writer, img image.Image, format string) error {
+	if format == CoverFormatWebP {
+		return nativewebp.Encode(w, img, nil)
+	}
+	return EncodeCoverJPEG(w, img)
+}
+
+// EncodeCoverJPEG writes a cover image as JPEG
+func EncodeCoverJPEG(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: coverQuality})
+}
+
+// CoverKey is the blob key of a cover uploaded for a book, or of one of its thumbnails when thumbnail is not empty.
+// Every upload has its own keys, covers uploaded before that have an empty uploadID.
+func CoverKey(bookID string, uploadID string, thumbnail string) string {
+	if thumbnail == "" {
+		return CoverUploadPrefix(bookID, uploadID) + "original"
+	}
+	return CoverUploadPrefix(bookID, uploadID) + thumbnail + ".jpg"
+}
+
+// CoverUploadPrefix is the key prefix of the blobs of one cover upload of a book
+func CoverUploadPrefix(bookID string, uploadID string) string {
+	if uploadID == "" {
+		return CoverPrefix(bookID)
+	}
+	return CoverPrefix(bookID) + uploadID + "/"
+}
+
+// CoverPrefix is the key prefix of every blob stored for the covers of a book
+func CoverPrefix(bookID string) string {
+	return "covers/" + bookID + "/"
+}
+
+// CoverUploadID returns the upload a cover URL of a book points to, ok is false for URLs not served by the
+// media routes. Covers uploaded before per-upload keys have an empty upload ID.
+func CoverUploadID(bookID string, url string) (uploadID string, ok bool) {
+	path := "/media/covers/" + bookID
+	index := strings.Index(url, path)
+	if index < 0 {
+		return "", false
+	}
+	rest, _, _ := strings.Cut(url[index+len(path):], "?")
+	if rest == "" {
+		return "", true
+	}
+	upload, err := primitive.ObjectIDFromHex(strings.TrimPrefix(rest, "/"))
+	if err != nil || !strings.HasPrefix(rest, "/") {
+		return "", false
+	}
+	return upload.Hex(), true
+}
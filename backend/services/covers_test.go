@@ -0,0 +1,39 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoverUploadID(t *testing.T) {
+	id := primitive.NewObjectID()
+	upload := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name   string
+		url    string
+		upload string
+		ok     bool
+	}{
+		{"upload", "https://library.example.com/media/covers/" + id.Hex() + "/" + upload, upload, true},
+		{"thumbnail", "/media/covers/" + id.Hex() + "/" + upload + "?size=small", upload, true},
+		{"upper case", "/media/covers/" + id.Hex() + "/" + strings.ToUpper(upload), upload, true},
+		{"before per-upload keys", "/media/covers/" + id.Hex(), "", true},
+		{"before per-upload keys thumbnail", "/media/covers/" + id.Hex() + "?size=small", "", true},
+		{"other book", "/media/covers/" + primitive.NewObjectID().Hex() + "/" + upload, "", false},
+		{"external", "https://images.example.com/cover.jpg", "", false},
+		{"not an upload", "/media/covers/" + id.Hex() + "/../other", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload, ok := CoverUploadID(id.Hex(), tt.url)
+			if upload != tt.upload || ok != tt.ok {
+				t.Fatalf("CoverUploadID(%q) = %q, %v, want %q, %v", tt.url, upload, ok, tt.upload, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"library-books/entity"
+	"math"
+	"time"
+)
+
+// FineRule is the late fee of a kind of material. Rate is charged per started day past the due date,
+// the first GraceDays are free and a Cap above zero limits the fine of a single loan.
+type FineRule struct {
+	Rate      float64
+	GraceDays int
+	Cap       float64
+}
+
+// FinePolicy holds the default late fee and the overrides per material, read from the fines section of config.json
+type FinePolicy struct {
+	Default   FineRule
+	Materials map[string]FineRule
+}
+
+// Rule returns the late fee of a material, materials without an override use the default
+func (p FinePolicy) Rule(material string) FineRule {
+	if rule, ok := p.Materials[material]; ok {
+		return rule
+	}
+	return p.Default
+}
+
+// Fine returns the days a loan is overdue at the given time and the fine accrued so far,
+// a returned loan stops accruing at its return
+func (p FinePolicy) Fine(loan entity.Loans, at time.Time) (days int, amount float64) {
+	if loan.ReturnedAt != nil {
+		at = *loan.ReturnedAt
+	}
+	late := at.Sub(loan.DueAt)
+	if late <= 0 {
+		return 0, 0
+	}
+
+	days = int(math.Ceil(late.Hours() / 24))
+	rule := p.Rule(loan.Material)
+	charged := days - rule.GraceDays
+	if charged <= 0 {
+		return days, 0
+	}
+
+	amount = rule.Rate * float64(charged)
+	if rule.Cap > 0 {
+		amount = math.Min(amount, rule.Cap)
+	}
+	return days, RoundCents(amount)
+}
+
+// RoundCents rounds an amount of money to two decimals
+func RoundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"library-books/entity"
+	"testing"
+	"time"
+)
+
+func TestFinePolicyFine(t *testing.T) {
+	policy := FinePolicy{
+		Default: FineRule{Rate: 0.5, GraceDays: 2, Cap: 5},
+		Materials: map[string]FineRule{
+			"dvd": {Rate: 1, Cap: 3},
+		},
+	}
+	dueAt := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		material string
+		returned time.Duration
+		days     int
+		amount   float64
+	}{
+		{"returned before due", "", -day, 0, 0},
+		{"returned at due", "", 0, 0, 0},
+		{"within grace days", "", 2 * day, 2, 0},
+		{"started day counts", "", 2*day + time.Hour, 3, 0.5},
+		{"below cap", "", 5 * day, 5, 1.5},
+		{"exactly at cap", "", 12 * day, 12, 5},
+		{"above cap", "", 40 * day, 40, 5},
+		{"material override", "dvd", 2 * day, 2, 2},
+		{"material override cap", "dvd", 10 * day, 10, 3},
+		{"material without override", "magazine", 5 * day, 5, 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returnedAt := dueAt.Add(tt.returned)
+			loan := entity.Loans{Material: tt.material, DueAt: dueAt, ReturnedAt: &returnedAt}
+
+			// a returned loan stops accruing, whenever the fine is computed
+			days, amount := policy.Fine(loan, returnedAt.Add(30*day))
+			if days != tt.days || amount != tt.amount {
+				t.Fatalf("Fine() = %d, %v, want %d, %v", days, amount, tt.days, tt.amount)
+			}
+		})
+	}
+}
+
+func TestFinePolicyFineActiveLoan(t *testing.T) {
+	policy := FinePolicy{Default: FineRule{Rate: 0.25, GraceDays: 1}}
+	dueAt := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	loan := entity.Loans{DueAt: dueAt}
+
+	if days, amount := policy.Fine(loan, dueAt.Add(-time.Hour)); days != 0 || amount != 0 {
+		t.Fatalf("before due Fine() = %d, %v", days, amount)
+	}
+	// without a cap the fine keeps growing
+	if days, amount := policy.Fine(loan, dueAt.Add(100*24*time.Hour)); days != 100 || amount != 24.75 {
+		t.Fatalf("overdue Fine() = %d, %v, want 100, 24.75", days, amount)
+	}
+}
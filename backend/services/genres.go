@@ -0,0 +1,59 @@
+package services
+
+import (
+	"library-books/entity"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// GenreDescendants returns the slug followed by the slugs of every genre below it in the taxonomy
+func GenreDescendants(genres []entity.Genres, slug string) []string {
+	children := map[string][]string{}
+	for _, genre := range genres {
+		if genre.Parent != "" {
+			children[genre.Parent] = append(children[genre.Parent], genre.Slug)
+		}
+	}
+
+	descendants := []string{slug}
+	seen := map[string]bool{slug: true}
+	for i := 0; i < len(descendants); i++ {
+		for _, child := range children[descendants[i]] {
+			if !seen[child] {
+				seen[child] = true
+				descendants = append(descendants, child)
+			}
+		}
+	}
+	return descendants
+}
+
+// LocalizeGenres fills the label of each genre for the preferred languages, given as language tags or
+// Accept-Language values. The labels are loaded as go-i18n messages keyed by slug, so the language is
+// matched like the other messages of the API. Genres without a label in that language use the English
+// label, then the slug.
+func LocalizeGenres(genres []entity.Genres, languages ...string) {
+	bundle := i18n.NewBundle(language.English)
+	for _, genre := range genres {
+		for tag, label := range genre.Labels {
+			parsed, err := language.Parse(tag)
+			if err != nil {
+				continue
+			}
+			bundle.AddMessages(parsed, &i18n.Message{ID: genre.Slug, Other: label})
+		}
+	}
+
+	localizer := i18n.NewLocalizer(bundle, languages...)
+	for i := range genres {
+		label, err := localizer.Localize(&i18n.LocalizeConfig{MessageID: genres[i].Slug})
+		if err != nil {
+			label = genres[i].Labels["en"]
+		}
+		if label == "" {
+			label = genres[i].Slug
+		}
+		genres[i].Label = label
+	}
+}
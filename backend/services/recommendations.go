@@ -0,0 +1,276 @@
+package services
+
+import (
+	"library-books/entity"
+	"math"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecommendationLimit is the number of books kept for each book and each user by a refresh
+const RecommendationLimit = 20
+
+// weights of the signals adding up to the similarity of two books, readers who borrowed or shelved
+// both books say more than a shared author or genre
+const (
+	authorWeight   = 2.0
+	genreWeight    = 1.0
+	coShelfWeight  = 3.0
+	coBorrowWeight = 4.0
+)
+
+// metadataNeighbours bounds the books of the same author or genre compared with a book to the
+// ones closest in publication year, so large genres do not compare every pair of books
+const metadataNeighbours = 50
+
+// basketLimit leaves out the shelves and borrowing histories holding more books than this,
+// they relate too many books to say much about any two of them
+const basketLimit = 200
+
+// Recommender scores the similarity of books from their authors and genres and from the books
+// readers keep on the same shelf or borrow, then recommends books to the readers from what they
+// shelved and borrowed
+type Recommender struct {
+	books     map[primitive.ObjectID]entity.Books
+	order     []primitive.ObjectID
+	shelves   map[primitive.ObjectID]map[primitive.ObjectID]bool
+	borrowers map[string]map[primitive.ObjectID]bool
+	readers   map[string]map[primitive.ObjectID]bool
+}
+
+// NewRecommender returns a Recommender over the given catalogue, shelvings and borrowings of other
+// books are ignored
+func NewRecommender(books []entity.Books) *Recommender {
+	r := &Recommender{
+		books:     map[primitive.ObjectID]entity.Books{},
+		shelves:   map[primitive.ObjectID]map[primitive.ObjectID]bool{},
+		borrowers: map[string]map[primitive.ObjectID]bool{},
+		readers:   map[string]map[primitive.ObjectID]bool{},
+	}
+	for _, book := range books {
+		if _, ok := r.books[book.ID]; !ok {
+			r.order = append(r.order, book.ID)
+		}
+		r.books[book.ID] = book
+	}
+	return r
+}
+
+// AddShelving records a book on a shelf of a user
+func (r *Recommender) AddShelving(userID string, shelfID, bookID primitive.ObjectID) {
+	if _, ok := r.books[bookID]; !ok {
+		return
+	}
+	addToBasket(r.shelves, shelfID, bookID)
+	addToBasket(r.readers, userID, bookID)
+}
+
+// AddBorrowing records a loan of a book to a user
+func (r *Recommender) AddBorrowing(userID string, bookID primitive.ObjectID) {
+	if _, ok := r.books[bookID]; !ok {
+		return
+	}
+	addToBasket(r.borrowers, userID, bookID)
+	addToBasket(r.readers, userID, bookID)
+}
+
+// Similar returns the books most similar to each book, at most RecommendationLimit per book.
+// A book is never similar to itself or to another edition of its work.
+func (r *Recommender) Similar() map[primitive.ObjectID][]entity.ScoredBook {
+	scores := map[primitive.ObjectID]map[primitive.ObjectID]float64{}
+	add := func(a, b primitive.ObjectID, score float64) {
+		if scores[a] == nil {
+			scores[a] = map[primitive.ObjectID]float64{}
+		}
+		scores[a][b] += score
+	}
+
+	// books by the same author score once even when they share several authors
+	sharedAuthor := map[[2]primitive.ObjectID]bool{}
+	for _, group := range r.groupBooks(authorKeys) {
+		for i, a := range group {
+			for _, b := range neighbours(group, i) {
+				if !sharedAuthor[[2]primitive.ObjectID{a, b}] {
+					sharedAuthor[[2]primitive.ObjectID{a, b}] = true
+					add(a, b, authorWeight)
+				}
+			}
+		}
+	}
+	for _, group := range r.groupBooks(genreKeys) {
+		for i, a := range group {
+			for _, b := range neighbours(group, i) {
+				add(a, b, genreWeight)
+			}
+		}
+	}
+	for _, baskets := range []struct {
+		weight float64
+		sets   []map[primitive.ObjectID]bool
+	}{
+		{coShelfWeight, basketValues(r.shelves)},
+		{coBorrowWeight, basketValues(r.borrowers)},
+	} {
+		for pair, cosine := range cooccurrence(baskets.sets) {
+			add(pair[0], pair[1], baskets.weight*cosine)
+		}
+	}
+
+	similar := map[primitive.ObjectID][]entity.ScoredBook{}
+	for _, id := range r.order {
+		book := r.books[id]
+		candidates := map[primitive.ObjectID]float64{}
+		for other, score := range scores[id] {
+			if other != id && !sameWork(book, r.books[other]) {
+				candidates[other] = score
+			}
+		}
+		if len(candidates) > 0 {
+			similar[id] = topScores(candidates)
+		}
+	}
+	return similar
+}
+
+// ForUsers recommends to each reader the books most similar to the books they shelved or borrowed,
+// at most RecommendationLimit per reader. The scores of a book similar to several of their books add up,
+// the books they already have and the other editions of their works are left out.
+func (r *Recommender) ForUsers(similar map[primitive.ObjectID][]entity.ScoredBook) map[string][]entity.ScoredBook {
+	recommendations := map[string][]entity.ScoredBook{}
+	for userID, seeds := range r.readers {
+		works := map[primitive.ObjectID]bool{}
+		for seed := range seeds {
+			if work := r.books[seed].WorkID; work != nil {
+				works[*work] = true
+			}
+		}
+
+		candidates := map[primitive.ObjectID]float64{}
+		for seed := range seeds {
+			for _, scored := range similar[seed] {
+				if seeds[scored.BookID] {
+					continue
+				}
+				if work := r.books[scored.BookID].WorkID; work != nil && works[*work] {
+					continue
+				}
+				candidates[scored.BookID] += scored.Score
+			}
+		}
+		if len(candidates) > 0 {
+			recommendations[userID] = topScores(candidates)
+		}
+	}
+	return recommendations
+}
+
+// groupBooks groups the books sharing a key, each group ordered by publication year
+func (r *Recommender) groupBooks(keys func(book entity.Books) []string) map[string][]primitive.ObjectID {
+	groups := map[string][]primitive.ObjectID{}
+	for _, id := range r.order {
+		for _, key := range keys(r.books[id]) {
+			groups[key] = append(groups[key], id)
+		}
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return r.books[group[i]].Year < r.books[group[j]].Year
+		})
+	}
+	return groups
+}
+
+// authorKeys identifies the authors of a book by their IDs, or by the author name of books not linked to authors
+func authorKeys(book entity.Books) []string {
+	if len(book.AuthorIDs) > 0 {
+		keys := make([]string, len(book.AuthorIDs))
+		for i, id := range book.AuthorIDs {
+			keys[i] = id.Hex()
+		}
+		return keys
+	}
+	if name := strings.Join(strings.Fields(strings.ToLower(book.Author)), " "); name != "" {
+		return []string{name}
+	}
+	return nil
+}
+
+func genreKeys(book entity.Books) []string {
+	if book.Genre == "" {
+		return nil
+	}
+	return []string{book.Genre}
+}
+
+// neighbours returns the books around the i-th book of a group ordered by year, at most metadataNeighbours
+func neighbours(group []primitive.ObjectID, i int) []primitive.ObjectID {
+	start := max(0, i-metadataNeighbours/2)
+	end := min(len(group), start+metadataNeighbours+1)
+	start = max(0, end-metadataNeighbours-1)
+
+	around := make([]primitive.ObjectID, 0, end-start-1)
+	around = append(around, group[start:i]...)
+	return append(around, group[i+1:end]...)
+}
+
+// cooccurrence returns the cosine similarity of each ordered pair of books found together in a basket,
+// the number of baskets holding both over the geometric mean of the baskets holding each
+func cooccurrence(baskets []map[primitive.ObjectID]bool) map[[2]primitive.ObjectID]float64 {
+	counts := map[primitive.ObjectID]int{}
+	pairs := map[[2]primitive.ObjectID]int{}
+	for _, basket := range baskets {
+		if len(basket) > basketLimit {
+			continue
+		}
+		for a := range basket {
+			counts[a]++
+			for b := range basket {
+				if a != b {
+					pairs[[2]primitive.ObjectID{a, b}]++
+				}
+			}
+		}
+	}
+
+	cosines := make(map[[2]primitive.ObjectID]float64, len(pairs))
+	for pair, count := range pairs {
+		cosines[pair] = float64(count) / math.Sqrt(float64(counts[pair[0]]*counts[pair[1]]))
+	}
+	return cosines
+}
+
+// topScores returns the RecommendationLimit best scored books, ties broken by book ID
+func topScores(candidates map[primitive.ObjectID]float64) []entity.ScoredBook {
+	scored := make([]entity.ScoredBook, 0, len(candidates))
+	for id, score := range candidates {
+		scored = append(scored, entity.ScoredBook{BookID: id, Score: math.Round(score*1000) / 1000})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].BookID.Hex() < scored[j].BookID.Hex()
+	})
+	return scored[:min(len(scored), RecommendationLimit)]
+}
+
+func sameWork(a, b entity.Books) bool {
+	return a.WorkID != nil && b.WorkID != nil && *a.WorkID == *b.WorkID
+}
+
+func addToBasket[K comparable](baskets map[K]map[primitive.ObjectID]bool, key K, bookID primitive.ObjectID) {
+	if baskets[key] == nil {
+		baskets[key] = map[primitive.ObjectID]bool{}
+	}
+	baskets[key][bookID] = true
+}
+
+func basketValues[K comparable](baskets map[K]map[primitive.ObjectID]bool) []map[primitive.ObjectID]bool {
+	values := make([]map[primitive.ObjectID]bool, 0, len(baskets))
+	for _, basket := range baskets {
+		values = append(values, basket)
+	}
+	return values
+}
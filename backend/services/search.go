@@ -0,0 +1,102 @@
+package services
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	highlightOpen     = "<em>"
+	highlightClose    = "</em>"
+	fragmentRadius    = 60
+	maxFragmentsField = 3
+)
+
+// QueryStems returns the distinct stems of the words in a search query
+func QueryStems(query string, lang string) map[string]bool {
+	stems := map[string]bool{}
+	for _, word := range Tokenize(query) {
+		if len(word) < 2 {
+			continue
+		}
+		stems[Stem(word, lang)] = true
+	}
+	return stems
+}
+
+// Highlight returns the fragments of text that contain words sharing a stem with the query, as HTML:
+// the text is escaped and the matched words are wrapped in <em></em>. Short texts are returned as a single fragment.
+func Highlight(text string, stems map[string]bool, lang string) []string {
+	type span struct{ start, end int }
+
+	matches := []span{}
+	start := -1
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		if isWord && start < 0 {
+			start = i
+		}
+		if !isWord && start >= 0 {
+			if stems[Stem(strings.ToLower(text[start:i]), lang)] {
+				matches = append(matches, span{start, i})
+			}
+			start = -1
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// group matches into fragments of text around them
+	fragments := []string{}
+	for i := 0; i < len(matches) && len(fragments) < maxFragmentsField; {
+		from := wordBoundary(text, matches[i].start-fragmentRadius, false)
+		to := wordBoundary(text, matches[i].end+fragmentRadius, true)
+
+		var fragment strings.Builder
+		if from > 0 {
+			fragment.WriteString("…")
+		}
+		cursor := from
+		for ; i < len(matches) && matches[i].start < to; i++ {
+			fragment.WriteString(html.EscapeString(text[cursor:matches[i].start]))
+			fragment.WriteString(highlightOpen + html.EscapeString(text[matches[i].start:matches[i].end]) + highlightClose)
+			cursor = matches[i].end
+			if matches[i].end > to {
+				to = matches[i].end
+			}
+		}
+		fragment.WriteString(html.EscapeString(text[cursor:to]))
+		if to < len(text) {
+			fragment.WriteString("…")
+		}
+		fragments = append(fragments, strings.TrimSpace(fragment.String()))
+	}
+	return fragments
+}
+
+// wordBoundary moves an offset to the nearest space so fragments do not cut words
+func wordBoundary(text string, offset int, forward bool) int {
+	if offset <= 0 {
+		return 0
+	}
+	if offset >= len(text) {
+		return len(text)
+	}
+	for offset > 0 && offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+
+	if forward {
+		if index := strings.IndexByte(text[offset:], ' '); index >= 0 {
+			return offset + index
+		}
+		return len(text)
+	}
+	if index := strings.LastIndexByte(text[:offset], ' '); index >= 0 {
+		return index + 1
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		lang  string
+		want  []string
+	}{
+		{"stemmed match", "Readers reading about a reader", "read", LanguageEnglish, []string{"Readers <em>reading</em> about a reader"}},
+		{"no match", "Laskar Pelangi", "bumi", LanguageIndonesian, nil},
+		{"indonesian affixes", "Anak-anak membaca buku", "baca", LanguageIndonesian, []string{"Anak-anak <em>membaca</em> buku"}},
+		{
+			"markup is escaped",
+			`<script>alert("x")</script> magic <img src=x onerror=alert(1)>`,
+			"magic",
+			LanguageEnglish,
+			[]string{`&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <em>magic</em> &lt;img src=x onerror=alert(1)&gt;`},
+		},
+		{"escaped match", "Tom & Jerry", "jerry", LanguageEnglish, []string{"Tom &amp; <em>Jerry</em>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Highlight(tt.text, QueryStems(tt.query, tt.lang), tt.lang)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextIndexLanguage(t *testing.T) {
+	for lang, want := range map[string]string{
+		"":      "english",
+		"en":    "english",
+		"en-GB": "english",
+		"id":    "none",
+		"id-ID": "none",
+		"ID":    "none",
+		"fr":    "english",
+	} {
+		if got := TextIndexLanguage(lang); got != want {
+			t.Errorf("TextIndexLanguage(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+// Tokenize splits a text into lowercase words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// TextIndexLanguage is the language the MongoDB text index stems a book in, from its BCP 47 language.
+// MongoDB has no Indonesian rules, Indonesian books are indexed without stemming and matched through the
+// stems added to Indonesian queries. Books in other languages or without one are stemmed as English.
+func TextIndexLanguage(lang string) string {
+	base, _, _ := strings.Cut(strings.ToLower(lang), "-")
+	if base == LanguageIndonesian {
+		return "none"
+	}
+	return "english"
+}
+
+// Stem reduces a lowercase word to its stem for the given language
+func Stem(word string, lang string) string {
+	if lang == LanguageIndonesian {
+		return stemIndonesian(word)
+	}
+	return stemEnglish(word)
+}
+
+// stemEnglish is a light suffix stripping stemmer, close enough to match plurals and common verb forms
+func stemEnglish(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case len(word) > 5 && strings.HasSuffix(word, "ing"):
+		return undouble(strings.TrimSuffix(word, "ing"))
+	case len(word) > 4 && strings.HasSuffix(word, "ed"):
+		return undouble(strings.TrimSuffix(word, "ed"))
+	case len(word) > 4 && strings.HasSuffix(word, "ly"):
+		return strings.TrimSuffix(word, "ly")
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// undouble turns the doubled final consonant of a stripped verb back into one, e.g. runn -> run
+func undouble(word string) string {
+	n := len(word)
+	if n > 2 && word[n-1] == word[n-2] && !strings.ContainsAny(word[n-1:], "aiueolsz") {
+		return word[:n-1]
+	}
+	return word
+}
+
+// stemIndonesian removes inflectional and derivational affixes following the Nazief-Adriani
+// order (particle, possessive, suffix, prefix) without a root word dictionary
+func stemIndonesian(word string) string {
+	word = trimSuffixes(word, "lah", "kah", "tah", "pun")
+	word = trimSuffixes(word, "nya", "ku", "mu")
+	word = trimSuffixes(word, "kan", "an", "i")
+
+	for i := 0; i < 2; i++ {
+		word = trimIndonesianPrefix(word)
+	}
+	return word
+}
+
+// trimSuffixes removes the first matching suffix as long as a meaningful stem remains
+func trimSuffixes(word string, suffixes ...string) string {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+// trimIndonesianPrefix removes a single prefix and restores the initial letter lost by
+// nasal assimilation, e.g. menulis -> tulis, memukul -> pukul, menyapu -> sapu
+func trimIndonesianPrefix(word string) string {
+	isVowel := func(s string) bool { return s != "" && strings.ContainsAny(s[:1], "aiueo") }
+
+	for _, prefix := range []string{"meng", "peng", "meny", "peny", "mem", "pem", "men", "pen", "ber", "ter", "per", "me", "pe", "be", "di", "ke", "se"} {
+		if !strings.HasPrefix(word, prefix) || len(word)-len(prefix) < 3 {
+			continue
+		}
+
+		rest := strings.TrimPrefix(word, prefix)
+		switch prefix {
+		case "meny", "peny":
+			return "s" + rest
+		case "mem", "pem":
+			if isVowel(rest) {
+				return "p" + rest
+			}
+		case "men", "pen":
+			if isVowel(rest) {
+				return "t" + rest
+			}
+		}
+		return rest
+	}
+	return word
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrBlobNotFound is returned when no blob is stored under the requested key
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrInvalidBlobKey is returned for keys that are empty, absolute or climb out of the store with ".."
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// BlobInfo describes a stored blob
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModifiedAt  time.Time
+}
+
+// BlobStore keeps binary files such as cover images under slash separated keys like "covers/<id>/original".
+// The local filesystem driver is the only one for now, an S3 compatible driver maps keys to object names.
+type BlobStore interface {
+	// Put stores the content under key, replacing any blob already there
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	// Get opens the blob stored under key, the caller closes the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	// Delete removes the blob stored under key, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes every blob whose key starts with prefix, such as "covers/<id>/"
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// validKey reports whether key is a relative slash separated path staying inside the store
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore returns a BlobStore that keeps blobs as files under the root directory, creating it when missing.
+// Each blob is written to a temporary file renamed into place, readers never see a partly written blob.
+// Files carry no content type, Get sniffs it from the first bytes of the file.
+func NewLocalBlobStore(root string) (BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+func (s *localBlobStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return writeFile(path, content)
+}
+
+func (s *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error) {
+	info := BlobInfo{Key: key}
+	path, err := s.path(key)
+	if err != nil {
+		return nil, info, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, info, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, info, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, info, err
+	}
+	info.Size = stat.Size()
+	info.ModifiedAt = stat.ModTime().UTC()
+
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	info.ContentType = http.DetectContentType(head[:n])
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, info, err
+	}
+	return file, info, nil
+}
+
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *localBlobStore) DeletePrefix(ctx context.Context, prefix string) error {
+	directory := prefix[:strings.LastIndex(prefix, "/")+1]
+	if directory != "" && !validKey(strings.TrimSuffix(directory, "/")) {
+		return ErrInvalidBlobKey
+	}
+
+	start := filepath.Join(s.root, filepath.FromSlash(directory))
+	err := filepath.WalkDir(start, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		key, err := filepath.Rel(s.root, name)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(filepath.ToSlash(key), prefix) {
+			return os.Remove(name)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *localBlobStore) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidBlobKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// writeFile writes content to a temporary file next to path and renames it over path
+func writeFile(path string, content io.Reader) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// AuthorNameKey reduces an author name to the form used to match name variants: "Last, First" is turned
+// into "First Last", accents, case and punctuation are dropped, so "Rowling, J. K." and "J.K. Rowling"
+// both become "j k rowling"
+func AuthorNameKey(name string) string {
+	if last, first, ok := strings.Cut(name, ","); ok {
+		name = first + " " + last
+	}
+	return strings.Join(foldWords(name), " ")
+}
+
+// foldWords splits text into lowercase words without accents, punctuation separates words
+func foldWords(text string) []string {
+	var folded strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accent left by the decomposition
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			folded.WriteRune(r)
+		default:
+			folded.WriteRune(' ')
+		}
+	}
+	return strings.Fields(folded.String())
+}
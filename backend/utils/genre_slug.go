@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var genreSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GenreSlug turns a genre name into the slug that identifies it in the taxonomy,
+// "Science Fiction" and "science-fiction" both become "science-fiction"
+func GenreSlug(name string) string {
+	return strings.Join(foldWords(name), "-")
+}
+
+// IsGenreSlug reports whether value is a genre slug: lowercase ASCII letters and digits separated by single hyphens
+func IsGenreSlug(value string) bool {
+	return genreSlugPattern.MatchString(value)
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// isbnPrefix matches the "ISBN", "ISBN:", "ISBN-10:" and "ISBN-13:" labels printed before an ISBN
+var isbnPrefix = regexp.MustCompile(`^ISBN(-1[03])?:?`)
+
+// CleanISBN strips the "ISBN", "ISBN-10" or "ISBN-13" prefix and keeps the digits and the uppercased check digit X,
+// so the digits of the prefix are never taken for the number
+func CleanISBN(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = isbnPrefix.ReplaceAllString(value, "")
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == 'X' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// IsISBN10 reports whether value is an ISBN-10 with a valid checksum, hyphens are allowed
+func IsISBN10(value string) bool {
+	isbn := CleanISBN(value)
+	if len(isbn) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i, r := range isbn {
+		digit := int(r - '0')
+		if r == 'X' && i == 9 {
+			digit = 10
+		} else if r < '0' || r > '9' {
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// IsISBN13 reports whether value is an ISBN-13 with a valid checksum, hyphens are allowed
+func IsISBN13(value string) bool {
+	isbn := CleanISBN(value)
+	if len(isbn) != 13 || !isDigits(isbn) {
+		return false
+	}
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+	return isbn13CheckDigit(isbn[:12]) == isbn[12]
+}
+
+// IsISBN reports whether value is a valid ISBN-10 or ISBN-13
+func IsISBN(value string) bool {
+	return IsISBN10(value) || IsISBN13(value)
+}
+
+// NormalizeISBN converts a valid ISBN-10 or ISBN-13 into a hyphen-free ISBN-13,
+// the second result is false when value is not a valid ISBN
+func NormalizeISBN(value string) (string, bool) {
+	isbn := CleanISBN(value)
+	switch {
+	case IsISBN13(isbn):
+		return isbn, true
+	case IsISBN10(isbn):
+		prefix := "978" + isbn[:9]
+		return prefix + string(isbn13CheckDigit(prefix)), true
+	}
+	return "", false
+}
+
+// ISBN13To10 converts a 978 prefixed ISBN-13 into its ISBN-10 form,
+// the second result is false when no ISBN-10 exists for value
+func ISBN13To10(value string) (string, bool) {
+	isbn := CleanISBN(value)
+	if !IsISBN13(isbn) || !strings.HasPrefix(isbn, "978") {
+		return "", false
+	}
+
+	body := isbn[3:12]
+	sum := 0
+	for i, r := range body {
+		sum += int(r-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", true
+	}
+	return body + string(rune('0'+check)), true
+}
+
+// isbn13CheckDigit computes the check digit for the first 12 digits of an ISBN-13
+func isbn13CheckDigit(digits string) byte {
+	sum := 0
+	for i, r := range digits {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package utils
+
+import "testing"
+
+func TestCleanISBN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"978-0-306-40615-7", "9780306406157"},
+		{"978 0 306 40615 7", "9780306406157"},
+		{" 0-8044-2957-x ", "080442957X"},
+		{"ISBN 978-0-306-40615-7", "9780306406157"},
+		{"ISBN:0-306-40615-2", "0306406152"},
+		{"ISBN-13: 978-0-306-40615-7", "9780306406157"},
+		{"isbn-13 978-0-306-40615-7", "9780306406157"},
+		{"ISBN-10: 0-306-40615-2", "0306406152"},
+	}
+
+	for _, tt := range tests {
+		if got := CleanISBN(tt.value); got != tt.want {
+			t.Errorf("CleanISBN(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestISBNChecksum(t *testing.T) {
+	tests := []struct {
+		value  string
+		isbn10 bool
+		isbn13 bool
+	}{
+		{"0306406152", true, false},
+		{"0-306-40615-2", true, false},
+		{"080442957X", true, false},
+		{"0-8044-2957-x", true, false},
+		{"0306406153", false, false},
+		{"9780306406157", false, true},
+		{"978-979-3062-79-2", false, true},
+		{"ISBN-13: 978-979-3062-79-2", false, true},
+		{"ISBN-10: 0-306-40615-2", true, false},
+		{"978-979-3062-79-1", false, false},
+		{"9770306406154", false, false},
+		{"03064061X2", false, false},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsISBN10(tt.value); got != tt.isbn10 {
+			t.Errorf("IsISBN10(%q) = %v, want %v", tt.value, got, tt.isbn10)
+		}
+		if got := IsISBN13(tt.value); got != tt.isbn13 {
+			t.Errorf("IsISBN13(%q) = %v, want %v", tt.value, got, tt.isbn13)
+		}
+		if got := IsISBN(tt.value); got != (tt.isbn10 || tt.isbn13) {
+			t.Errorf("IsISBN(%q) = %v", tt.value, got)
+		}
+	}
+}
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"0-306-40615-2", "9780306406157", true},
+		{"080442957X", "9780804429573", true},
+		{"ISBN-10: 0 306 40615 2", "9780306406157", true},
+		{"978-0-306-40615-7", "9780306406157", true},
+		{"ISBN-13: 978-979-3062-79-2", "9789793062792", true},
+		{"0-306-40615-3", "", false},
+		{"not an isbn", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := NormalizeISBN(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeISBN(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestISBN13To10(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"978-0-306-40615-7", "0306406152", true},
+		{"9780804429573", "080442957X", true},
+		{"ISBN-13: 978-0-306-40615-7", "0306406152", true},
+		{"979-10-90636-07-1", "", false},
+		{"978-0-306-40615-8", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ISBN13To10(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ISBN13To10(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPatch is returned when a patch document is malformed or cannot be applied
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// MergePatch applies a JSON Merge Patch (RFC 7396) to a JSON document
+func MergePatch(document []byte, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(target, changes))
+}
+
+// mergeValue implements the MergePatch function of RFC 7396 section 2
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// JSONPatchOperation is a single operation of a JSON Patch document
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch applies a JSON Patch (RFC 6902) to a JSON document, operations are applied
+// in order and the whole patch fails when any operation fails
+func ApplyJSONPatch(document []byte, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []JSONPatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i, operation := range operations {
+		target, err = applyOperation(target, operation)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s %s): %v", ErrInvalidPatch, i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func applyOperation(document interface{}, operation JSONPatchOperation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (interface{}, error) {
+		if len(operation.Value) == 0 {
+			return nil, errors.New("missing value")
+		}
+		return decodeJSON(operation.Value)
+	}
+
+	switch operation.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return addValue(document, path, v)
+
+	case "remove":
+		document, _, err := removeValue(document, path)
+		return document, err
+
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		document, _, err := removeValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(document, path, v)
+
+	case "move":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		document, moved, err := removeValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(document, path, moved)
+
+	case "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		copied, err := getValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+		// copy through JSON so later operations do not change the source
+		data, _ := json.Marshal(copied)
+		copied, _ = decodeJSON(data)
+		return addValue(document, path, copied)
+
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		current, err := getValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalJSON(current, v) {
+			return nil, errors.New("test failed")
+		}
+		return document, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", operation.Op)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getValue(document interface{}, path []string) (interface{}, error) {
+	current := document
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", token)
+		}
+	}
+	return current, nil
+}
+
+// addValue sets the value at path, inserting into arrays and creating or replacing object members
+func addValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return document, nil
+	case []interface{}:
+		index := len(node)
+		if last != "-" {
+			index, err = arrayIndex(last, len(node))
+			if err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return setValue(document, path[:len(path)-1], node)
+	}
+	return nil, fmt.Errorf("path %q does not exist", last)
+}
+
+// removeValue deletes the value at path and returns the updated document with the removed value
+func removeValue(document interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, document, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path %q does not exist", last)
+		}
+		delete(node, last)
+		return document, value, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		document, err = setValue(document, path[:len(path)-1], node)
+		return document, value, err
+	}
+	return nil, nil, fmt.Errorf("path %q does not exist", last)
+}
+
+// setValue replaces the value at an existing path, used when an array grows or shrinks
+func setValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+	case []interface{}:
+		index, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+	}
+	return document, nil
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return index, nil
+}
+
+func isPrefix(prefix []string, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalJSON compares two decoded JSON values, numbers are compared by value
+func equalJSON(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		bNumber, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		aFloat, errA := a.Float64()
+		bFloat, errB := bNumber.Float64()
+		return errA == nil && errB == nil && aFloat == bFloat
+	case map[string]interface{}:
+		bObject, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(bObject) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := bObject[key]
+			if !ok || !equalJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bArray, ok := b.([]interface{})
+		if !ok || len(a) != len(bArray) {
+			return false
+		}
+		for i := range a {
+			if !equalJSON(a[i], bArray[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// decodeJSON decodes a JSON value keeping numbers as json.Number so they round trip unchanged
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
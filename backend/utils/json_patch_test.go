@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// jsonEqual compares two JSON documents by value
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("decode result %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("decode expected %s: %v", want, err)
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+// the examples of RFC 7396 appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		document string
+		patch    string
+		want     string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{`{"year":1980}`, `{"year":1980.0}`, `{"year":1980}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.document+" "+tt.patch, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Fatalf("MergePatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	if _, err := MergePatch([]byte(`{"a":"b"}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Fatalf("MergePatch() error = %v, want %v", err, ErrInvalidPatch)
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		want     string
+	}{
+		// the examples of RFC 6902 appendix A
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{
+			"move value",
+			`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"test value", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"add nested member", `{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{"ignore unknown members", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`, `{"foo":"bar","baz":"qux"}`},
+		{"escape ordering", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+		{"add array value", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+
+		{"escaped slash", `{"a/b":1}`, `[{"op":"replace","path":"/a~1b","value":2}]`, `{"a/b":2}`},
+		{"escaped tilde", `{"m~n":1}`, `[{"op":"remove","path":"/m~0n"}]`, `{}`},
+		{"append at length", `{"foo":["a"]}`, `[{"op":"add","path":"/foo/1","value":"b"}]`, `{"foo":["a","b"]}`},
+		{"add replaces member", `{"foo":"bar"}`, `[{"op":"add","path":"/foo","value":"baz"}]`, `{"foo":"baz"}`},
+		{"replace whole document", `{"foo":"bar"}`, `[{"op":"add","path":"","value":{"baz":1}}]`, `{"baz":1}`},
+		{"copy value", `{"foo":{"a":1}}`, `[{"op":"copy","from":"/foo","path":"/bar"},{"op":"replace","path":"/bar/a","value":2}]`, `{"foo":{"a":1},"bar":{"a":2}}`},
+		{"copy array element", `{"foo":["a","b"]}`, `[{"op":"copy","from":"/foo/0","path":"/foo/-"}]`, `{"foo":["a","b","a"]}`},
+		{"test number forms", `{"year":1980}`, `[{"op":"test","path":"/year","value":1980.0}]`, `{"year":1980}`},
+		{"operations in order", `{"tags":[]}`, `[{"op":"add","path":"/tags/-","value":"a"},{"op":"add","path":"/tags/0","value":"b"},{"op":"remove","path":"/tags/1"}]`, `{"tags":["b"]}`},
+		{"nested array", `{"a":[[1,2],[3]]}`, `[{"op":"remove","path":"/a/0/1"},{"op":"add","path":"/a/1/-","value":4}]`, `{"a":[[1],[3,4]]}`},
+		{"empty patch", `{"foo":"bar"}`, `[]`, `{"foo":"bar"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Fatalf("ApplyJSONPatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+	}{
+		// the error examples of RFC 6902 appendix A
+		{"failed test", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`},
+		{"add to missing parent", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`},
+		{"string is not number", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":"10"}]`},
+
+		{"not an array", `{}`, `{"op":"add","path":"/a","value":1}`},
+		{"malformed patch", `{}`, `[{"op":"add"`},
+		{"unknown operation", `{}`, `[{"op":"merge","path":"/a","value":1}]`},
+		{"missing value", `{}`, `[{"op":"add","path":"/a"}]`},
+		{"pointer without slash", `{"a":1}`, `[{"op":"remove","path":"a"}]`},
+		{"remove missing member", `{"a":1}`, `[{"op":"remove","path":"/b"}]`},
+		{"replace missing member", `{"a":1}`, `[{"op":"replace","path":"/b","value":2}]`},
+		{"index past length", `{"a":[1]}`, `[{"op":"add","path":"/a/2","value":2}]`},
+		{"remove past end", `{"a":[1]}`, `[{"op":"remove","path":"/a/1"}]`},
+		{"negative index", `{"a":[1]}`, `[{"op":"remove","path":"/a/-1"}]`},
+		{"leading zero index", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/01"}]`},
+		{"remove end marker", `{"a":[1]}`, `[{"op":"remove","path":"/a/-"}]`},
+		{"move into child", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`},
+		{"move missing member", `{"a":1}`, `[{"op":"move","from":"/b","path":"/c"}]`},
+		{"copy missing member", `{"a":1}`, `[{"op":"copy","from":"/b","path":"/c"}]`},
+		{"test missing member", `{"a":1}`, `[{"op":"test","path":"/b","value":1}]`},
+		{"path through scalar", `{"a":1}`, `[{"op":"add","path":"/a/b","value":1}]`},
+		{"later operation fails", `{"a":1}`, `[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch([]byte(tt.document), []byte(tt.patch))
+			if !errors.Is(err, ErrInvalidPatch) {
+				t.Fatalf("ApplyJSONPatch() = %s, %v, want %v", got, err, ErrInvalidPatch)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchKeepsDocument(t *testing.T) {
+	document := []byte(`{"a":[1,2]}`)
+	if _, err := ApplyJSONPatch(document, []byte(`[{"op":"remove","path":"/a/0"},{"op":"test","path":"/a","value":[]}]`)); err == nil {
+		t.Fatal("ApplyJSONPatch() error = nil, want test failure")
+	}
+	if string(document) != `{"a":[1,2]}` {
+		t.Fatalf("document changed to %s", document)
+	}
+}
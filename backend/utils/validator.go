@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidations adds the custom validation tags of the application to the shared validator:
+// isbn10, isbn13 and isbn accept hyphenated values and verify the checksum, genre_slug checks the form of a genre slug
+func RegisterValidations(validate *validator.Validate) error {
+	validations := map[string]func(value string) bool{
+		"isbn10":     IsISBN10,
+		"isbn13":     IsISBN13,
+		"isbn":       IsISBN,
+		"genre_slug": IsGenreSlug,
+	}
+
+	for tag, check := range validations {
+		check := check
+		err := validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return check(fl.Field().String())
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}